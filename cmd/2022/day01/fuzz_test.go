@@ -0,0 +1,24 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzCalcCalories checks that any list of calories is counted or rejected, without a panic.
+func FuzzCalcCalories(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("1000\n\n\n2000")
+	f.Add("-5\nx")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := CalcPart1Calories(lines)
+		parsetest.CheckError(t, err)
+
+		_, err = CalcPart2Calories(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+	"strconv"
+)
+
+func init() {
+	inputhandler.SetGenerator(250, generateElves)
+}
+
+// generateElves creates the snacks of size elves, separated by empty lines
+func generateElves(rng *rand.Rand, size int) inputhandler.Generated {
+
+	lines := make([]string, 0, size*5)
+	for elf := 0; elf < size; elf++ {
+		if elf > 0 {
+			lines = append(lines, "")
+		}
+		for snack := 1 + rng.Intn(15); snack > 0; snack-- {
+			lines = append(lines, strconv.Itoa(1000+rng.Intn(59000)))
+		}
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
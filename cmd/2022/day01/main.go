@@ -0,0 +1,109 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"strconv"
+)
+
+func main() {
+
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
+	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 1, "MaxCalories")
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	if inputhandler.IsPartRequested(1) {
+		maxPart1, err := CalcPart1Calories(lines)
+		if err != nil {
+			outputhandler.PrintError("Error", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part1", maxPart1)
+	}
+
+	if inputhandler.IsPartRequested(2) {
+		maxPart2, err := CalcPart2Calories(lines)
+		if err != nil {
+			outputhandler.PrintError("Error", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", maxPart2)
+	}
+
+	results.Print()
+}
+
+func CalcPart1Calories(lines []string) (int64, error) {
+
+	var max, curr int64
+	for lineIdx, line := range lines {
+
+		if len(line) == 0 {
+
+			if max < curr {
+				max = curr
+			}
+
+			curr = 0
+
+			continue
+		}
+
+		value, err := strconv.ParseInt(line, 10, 0)
+		if err != nil {
+			return 0, inputhandler.NewParseError(lineIdx, line, 0, "invalid calories value '%s'", line)
+		}
+
+		curr += value
+	}
+
+	if max < curr {
+		max = curr
+	}
+
+	return max, nil
+}
+
+func CalcPart2Calories(lines []string) (int64, error) {
+
+	var max = make([]int64, 3)
+	var curr int64
+	for lineIdx, line := range lines {
+
+		if len(line) == 0 {
+			for idx, _ := range max {
+				if max[idx] <= curr {
+					max[idx], curr = curr, max[idx]
+				}
+			}
+
+			curr = 0
+
+			continue
+		}
+
+		value, err := strconv.ParseInt(line, 10, 0)
+		if err != nil {
+			return 0, inputhandler.NewParseError(lineIdx, line, 0, "invalid calories value '%s'", line)
+		}
+
+		curr += value
+	}
+
+	for idx, _ := range max {
+		if max[idx] <= curr {
+			max[idx], curr = curr, max[idx]
+		}
+	}
+
+	return max[2] + max[1] + max[0], nil
+}
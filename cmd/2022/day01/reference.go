@@ -0,0 +1,45 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"sort"
+	"strconv"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference sums the calories of every elf, sorts the sums and adds up the
+// biggest ones, instead of keeping the top ones while reading.
+func solveReference(_ context.Context, lines []string, part int) (interface{}, error) {
+
+	sums := []int64{0}
+	for lineIdx, line := range lines {
+		if len(line) == 0 {
+			sums = append(sums, 0)
+			continue
+		}
+
+		value, err := strconv.ParseInt(line, 10, 0)
+		if err != nil {
+			return nil, inputhandler.NewParseError(lineIdx, line, 0, "invalid calories value '%s'", line)
+		}
+		sums[len(sums)-1] += value
+	}
+
+	sort.Slice(sums, func(i, j int) bool { return sums[i] > sums[j] })
+
+	count := 1
+	if part == 2 {
+		count = 3
+	}
+
+	var total int64
+	for idx := 0; idx < count && idx < len(sums); idx++ {
+		total += sums[idx]
+	}
+
+	return total, nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzCalcScore checks that any strategy guide is scored or rejected, without a panic.
+func FuzzCalcScore(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("A Y\nB")
+	f.Add("A X Z\nD W")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := CalcPart1Score(lines)
+		parsetest.CheckError(t, err)
+
+		_, err = CalcPart2Score(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(2500, generateRounds)
+}
+
+// generateRounds creates size rounds of the strategy guide
+func generateRounds(rng *rand.Rand, size int) inputhandler.Generated {
+
+	lines := make([]string, size)
+	for idx := range lines {
+		lines[idx] = string(rune('A'+rng.Intn(3))) + " " + string(rune('X'+rng.Intn(3)))
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
@@ -2,28 +2,45 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
 	"fmt"
-	"os"
 	"strings"
 )
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 2, "Scores")
 
-	scorePart1, err := CalcPart1Score(lines)
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if results.SolveReference(lines) {
+		results.Print()
+		return
 	}
 
-	scorePart2, err := CalcPart2Score(lines)
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(1) {
+		scorePart1, err := CalcPart1Score(lines)
+		if err != nil {
+			outputhandler.PrintError("Error", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part1", scorePart1)
 	}
 
-	fmt.Printf("Scores - Part1: %d, Part2: %d\n", scorePart1, scorePart2)
+	if inputhandler.IsPartRequested(2) {
+		scorePart2, err := CalcPart2Score(lines)
+		if err != nil {
+			outputhandler.PrintError("Error", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", scorePart2)
+	}
+
+	results.Print()
 }
 
 //-Part1-----------------------------------------------------------------------
@@ -31,11 +48,11 @@ func main() {
 func CalcPart1Score(lines []string) (int, error) {
 
 	var score int
-	for _, line := range lines {
+	for lineIdx, line := range lines {
 
-		elfHand, myHand, err := ReadHands(line)
+		elfHand, myHand, err := ReadHands(lineIdx, line)
 		if err != nil {
-			return 0, fmt.Errorf("invalid input in line '%s': %w", line, err)
+			return 0, err
 		}
 		score += HandPoints[myHand]
 
@@ -49,8 +66,11 @@ func CalcPart1Score(lines []string) (int, error) {
 	return score, nil
 }
 
-func ReadHands(line string) (ElfHand, MyHand, error) {
+func ReadHands(lineIdx int, line string) (ElfHand, MyHand, error) {
 	hands := strings.Fields(line)
+	if len(hands) != 2 {
+		return ElfInvalid, MyInvalid, inputhandler.NewParseError(lineIdx, line, -1, "expected 2 hands, found %d", len(hands))
+	}
 
 	var elfHand ElfHand = ElfInvalid
 	for _, hand := range ValidElfHands {
@@ -59,7 +79,7 @@ func ReadHands(line string) (ElfHand, MyHand, error) {
 		}
 	}
 	if elfHand == ElfInvalid {
-		return ElfInvalid, MyInvalid, ErrorInvalidHand
+		return ElfInvalid, MyInvalid, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 0), "%w '%s'", ErrorInvalidHand, hands[0])
 	}
 
 	var myHand MyHand = MyInvalid
@@ -69,7 +89,7 @@ func ReadHands(line string) (ElfHand, MyHand, error) {
 		}
 	}
 	if myHand == MyInvalid {
-		return ElfInvalid, MyInvalid, ErrorInvalidHand
+		return ElfInvalid, MyInvalid, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 1), "%w '%s'", ErrorInvalidHand, hands[1])
 	}
 
 	return elfHand, myHand, nil
@@ -91,11 +111,11 @@ func ResolveOutcome(elfHand ElfHand, myHand MyHand) (Outcome, error) {
 func CalcPart2Score(lines []string) (int, error) {
 
 	var score int
-	for _, line := range lines {
+	for lineIdx, line := range lines {
 
-		elfHand, expectedOutcome, err := ReadRoundPlan(line)
+		elfHand, expectedOutcome, err := ReadRoundPlan(lineIdx, line)
 		if err != nil {
-			return 0, fmt.Errorf("invalid input in line '%s': %w", line, err)
+			return 0, err
 		}
 
 		outcomePos := OutcomePosition[expectedOutcome]
@@ -112,8 +132,11 @@ func CalcPart2Score(lines []string) (int, error) {
 	return score, nil
 }
 
-func ReadRoundPlan(line string) (ElfHand, ExpectedOutcome, error) {
+func ReadRoundPlan(lineIdx int, line string) (ElfHand, ExpectedOutcome, error) {
 	plan := strings.Fields(line)
+	if len(plan) != 2 {
+		return ElfInvalid, ExpectedInvalid, inputhandler.NewParseError(lineIdx, line, -1, "expected a hand and an outcome, found %d values", len(plan))
+	}
 
 	var elfHand ElfHand = ElfInvalid
 	for _, hand := range ValidElfHands {
@@ -122,7 +145,7 @@ func ReadRoundPlan(line string) (ElfHand, ExpectedOutcome, error) {
 		}
 	}
 	if elfHand == ElfInvalid {
-		return ElfInvalid, ExpectedInvalid, ErrorInvalidHand
+		return ElfInvalid, ExpectedInvalid, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 0), "%w '%s'", ErrorInvalidHand, plan[0])
 	}
 
 	var expectedOutcome ExpectedOutcome = ExpectedInvalid
@@ -132,7 +155,7 @@ func ReadRoundPlan(line string) (ElfHand, ExpectedOutcome, error) {
 		}
 	}
 	if expectedOutcome == ExpectedInvalid {
-		return ElfInvalid, ExpectedInvalid, ErrorInvalidOutcome
+		return ElfInvalid, ExpectedInvalid, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 1), "%w '%s'", ErrorInvalidOutcome, plan[1])
 	}
 
 	return elfHand, expectedOutcome, nil
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// referenceScores are the scores of all the possible rounds written out by hand,
+// the shape's points plus 0, 3 or 6 for the outcome.
+var referenceScores = map[int]map[string]int{
+	1: { // the second column is my shape
+		"A X": 1 + 3, "A Y": 2 + 6, "A Z": 3 + 0,
+		"B X": 1 + 0, "B Y": 2 + 3, "B Z": 3 + 6,
+		"C X": 1 + 6, "C Y": 2 + 0, "C Z": 3 + 3,
+	},
+	2: { // the second column is the outcome, X to lose, Y to draw, Z to win
+		"A X": 3 + 0, "A Y": 1 + 3, "A Z": 2 + 6,
+		"B X": 1 + 0, "B Y": 2 + 3, "B Z": 3 + 6,
+		"C X": 2 + 0, "C Y": 3 + 3, "C Z": 1 + 6,
+	},
+}
+
+// solveReference looks up the score of every round in a table, no hands or outcomes.
+func solveReference(_ context.Context, lines []string, part int) (interface{}, error) {
+
+	var score int
+	for lineIdx, line := range lines {
+		roundScore, found := referenceScores[part][line]
+		if !found {
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid round '%s'", line)
+		}
+		score += roundScore
+	}
+
+	return score, nil
+}
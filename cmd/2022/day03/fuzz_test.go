@@ -0,0 +1,24 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzCalcResult checks that any list of rucksacks is processed or rejected, without a panic.
+func FuzzCalcResult(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("abc\naBcd")
+	f.Add("a1a1\nxx\nyy")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := calcPart1Result(lines)
+		parsetest.CheckError(t, err)
+
+		_, err = calcPart2Result(lines)
+		parsetest.CheckError(t, err)
+	})
+}
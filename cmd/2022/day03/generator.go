@@ -0,0 +1,78 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(300, generateRucksacks)
+}
+
+const rucksackItems = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// generateRucksacks creates groups of 3 rucksacks, at least size of them. The compartments
+// of a rucksack share exactly one item type, and the rucksacks of a group share exactly
+// one, the badge.
+func generateRucksacks(rng *rand.Rand, size int) inputhandler.Generated {
+
+	lines := make([]string, 0, size+2)
+	for len(lines) < size {
+
+		// every rucksack of the group picks its items from its own third of the types,
+		// so the badge is the only type they all have
+		items := []byte(rucksackItems)
+		rng.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+		badge, items := items[0], items[1:]
+
+		for rucksack := 0; rucksack < 3; rucksack++ {
+			pool := items[rucksack*17 : (rucksack+1)*17]
+			shared, first, second := pool[0], pool[1:9], pool[9:]
+
+			half := 4 + rng.Intn(13)
+			left := randomItems(rng, first, half)
+			right := randomItems(rng, second, half)
+			left[rng.Intn(half)] = shared
+			right[rng.Intn(half)] = shared
+
+			// the badge goes to one of the compartments, not the shared type's place
+			if rng.Intn(2) == 0 {
+				placeBadge(rng, left, badge, shared)
+			} else {
+				placeBadge(rng, right, badge, shared)
+			}
+
+			lines = append(lines, string(left)+string(right))
+		}
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
+
+func randomItems(rng *rand.Rand, pool []byte, count int) []byte {
+	items := make([]byte, count)
+	for idx := range items {
+		items[idx] = pool[rng.Intn(len(pool))]
+	}
+	return items
+}
+
+func placeBadge(rng *rand.Rand, compartment []byte, badge, shared byte) {
+	for {
+		idx := rng.Intn(len(compartment))
+		if compartment[idx] != shared || countItem(compartment, shared) > 1 {
+			compartment[idx] = badge
+			return
+		}
+	}
+}
+
+func countItem(compartment []byte, item byte) int {
+	count := 0
+	for _, other := range compartment {
+		if other == item {
+			count++
+		}
+	}
+	return count
+}
@@ -2,33 +2,50 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
 	"fmt"
-	"os"
 )
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 3, "Result")
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	if inputhandler.IsPartRequested(1) {
+		resultPart1, err := calcPart1Result(lines)
+		if err != nil {
+			outputhandler.PrintError("Error processing part 1", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
 
-	resultPart1, err := calcPart1Result(lines)
-	if err != nil {
-		fmt.Printf("Error processing part 1: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		results.Add("Part1", resultPart1)
 	}
 
-	resultPart2, err := calcPart2Result(lines)
-	if err != nil {
-		fmt.Printf("Error processing part 2: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(2) {
+		resultPart2, err := calcPart2Result(lines)
+		if err != nil {
+			outputhandler.PrintError("Error processing part 2", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", resultPart2)
 	}
 
-	fmt.Printf("Result - Part1: %d, Part2: %d", resultPart1, resultPart2)
+	results.Print()
 }
 
 func calcPart2Result(lines []string) (int, error) {
 
 	if len(lines)%3 != 0 {
-		return 0, fmt.Errorf("invalid line count '%d'", len(lines))
+		return 0, inputhandler.NewParseError(-1, "", -1, "invalid line count '%d', groups of 3 are needed", len(lines))
 	}
 
 	var result int
@@ -39,6 +56,10 @@ func calcPart2Result(lines []string) (int, error) {
 		groupChecklists := make([][]bool, 3)
 		for elfIdx, sacks := range groupSacks {
 
+			if err := validateItems(groupIdx+elfIdx, sacks); err != nil {
+				return 0, err
+			}
+
 			checklist, err := checklistItems(sacks)
 			if err != nil {
 				return 0, fmt.Errorf("error processing line '%s': %w", sacks, err)
@@ -55,7 +76,7 @@ func calcPart2Result(lines []string) (int, error) {
 			}
 		}
 		if badge == 0 {
-			return 0, fmt.Errorf("couldn't find bagde for lines '%s;%s;%s'", groupSacks[0], groupSacks[1], groupSacks[2])
+			return 0, inputhandler.NewParseError(groupIdx, groupSacks[0], -1, "couldn't find badge for the group of lines %d-%d", groupIdx+1, groupIdx+3)
 		}
 
 		result += badge
@@ -66,10 +87,14 @@ func calcPart2Result(lines []string) (int, error) {
 
 func calcPart1Result(lines []string) (int, error) {
 	var result int
-	for _, line := range lines {
+	for lineIdx, line := range lines {
 
 		if len(line)%2 != 0 {
-			return 0, fmt.Errorf("invalid line length '%d' for line '%s'", len(line), line)
+			return 0, inputhandler.NewParseError(lineIdx, line, -1, "invalid line length '%d', compartments must be the same size", len(line))
+		}
+
+		if err := validateItems(lineIdx, line); err != nil {
+			return 0, err
 		}
 
 		sackCount := len(line) / 2
@@ -96,7 +121,7 @@ func calcPart1Result(lines []string) (int, error) {
 		}
 
 		if mistake == 0 {
-			return 0, fmt.Errorf("couldn't find mistake n line '%s'", line)
+			return 0, inputhandler.NewParseError(lineIdx, line, -1, "couldn't find the mistake")
 		}
 
 		result += mistake
@@ -107,16 +132,28 @@ func calcPart1Result(lines []string) (int, error) {
 
 func getItemPriority(item rune) (int, error) {
 
-	switch intVal := int(item); {
-	case intVal >= int('a'):
-		return intVal - 96, nil
-	case intVal <= int('Z'):
-		return intVal - 64 + 26, nil
+	switch {
+	case item >= 'a' && item <= 'z':
+		return int(item) - 96, nil
+	case item >= 'A' && item <= 'Z':
+		return int(item) - 64 + 26, nil
 	}
 
 	return 0, fmt.Errorf("invalid character '%s'", string(item))
 }
 
+// validateItems checks the line for invalid characters, to report them with the position
+func validateItems(lineIdx int, line string) error {
+
+	for charIdx, char := range line {
+		if _, err := getItemPriority(char); err != nil {
+			return inputhandler.NewParseError(lineIdx, line, charIdx, "invalid item '%s'", string(char))
+		}
+	}
+
+	return nil
+}
+
 func checklistItems(compartment string) ([]bool, error) {
 
 	checklist := make([]bool, 53) // +1 so no need for -1 indexing everywhere
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// referencePriorities are the items in the order of their priority, from 1
+const referencePriorities = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// solveReference compares the items of the rucksacks with each other by searching
+// the strings, no checklists.
+func solveReference(_ context.Context, lines []string, part int) (interface{}, error) {
+
+	var sum int
+	if part == 1 {
+		for lineIdx, line := range lines {
+			comp1, comp2 := line[:len(line)/2], line[len(line)/2:]
+			item, found := findCommonItem(comp1, comp2)
+			if !found {
+				return nil, inputhandler.NewParseError(lineIdx, line, -1, "no item in both compartments")
+			}
+			sum += strings.IndexRune(referencePriorities, item) + 1
+		}
+		return sum, nil
+	}
+
+	if len(lines)%3 != 0 {
+		return nil, inputhandler.NewParseError(-1, "", -1, "invalid line count '%d', groups of 3 are needed", len(lines))
+	}
+	for groupIdx := 0; groupIdx < len(lines); groupIdx += 3 {
+		item, found := findCommonItem(lines[groupIdx : groupIdx+3]...)
+		if !found {
+			return nil, inputhandler.NewParseError(groupIdx, lines[groupIdx], -1, "no item in all 3 rucksacks")
+		}
+		sum += strings.IndexRune(referencePriorities, item) + 1
+	}
+
+	return sum, nil
+}
+
+// findCommonItem returns the first valid item of the first list that all the others have
+func findCommonItem(lists ...string) (rune, bool) {
+
+	for _, item := range lists[0] {
+		if !strings.ContainsRune(referencePriorities, item) {
+			continue
+		}
+
+		inAll := true
+		for _, list := range lists[1:] {
+			inAll = inAll && strings.ContainsRune(list, item)
+		}
+		if inAll {
+			return item, true
+		}
+	}
+
+	return 0, false
+}
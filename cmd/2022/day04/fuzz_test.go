@@ -0,0 +1,24 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzCountOverlapse checks that any list of assignments is processed or rejected, without a panic.
+func FuzzCountOverlapse(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("2-4,6-8\n1-2-3,4")
+	f.Add("-1--2,3-")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := countOverlapse(lines, isFullRangeOverlap)
+		parsetest.CheckError(t, err)
+
+		_, err = countOverlapse(lines, isPartialOverlap)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(1000, generateAssignmentPairs)
+}
+
+// generateAssignmentPairs creates size pairs of section assignments
+func generateAssignmentPairs(rng *rand.Rand, size int) inputhandler.Generated {
+
+	lines := make([]string, size)
+	for idx := range lines {
+		start1, end1 := randomSections(rng)
+		start2, end2 := randomSections(rng)
+		lines[idx] = fmt.Sprintf("%d-%d,%d-%d", start1, end1, start2, end2)
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
+
+func randomSections(rng *rand.Rand) (int, int) {
+	start := 1 + rng.Intn(99)
+	return start, start + rng.Intn(100-start)
+}
@@ -2,29 +2,46 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 )
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 4, "Overlaps")
 
-	overlapsPart1, err := countOverlapse(lines, isFullRangeOverlap)
-	if err != nil {
-		fmt.Printf("Error: while processing part 1: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if results.SolveReference(lines) {
+		results.Print()
+		return
 	}
 
-	overlapsPart2, err := countOverlapse(lines, isPartialOverlap)
-	if err != nil {
-		fmt.Printf("Error: while processing part 2: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(1) {
+		overlapsPart1, err := countOverlapse(lines, isFullRangeOverlap)
+		if err != nil {
+			outputhandler.PrintError("Error: while processing part 1", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part1", overlapsPart1)
+	}
+
+	if inputhandler.IsPartRequested(2) {
+		overlapsPart2, err := countOverlapse(lines, isPartialOverlap)
+		if err != nil {
+			outputhandler.PrintError("Error: while processing part 2", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", overlapsPart2)
 	}
 
-	fmt.Printf("Overlaps - Part1: %d, Part2: %d", overlapsPart1, overlapsPart2)
+	results.Print()
 }
 
 type overlapCheckFN func(Range, Range) bool
@@ -73,21 +90,21 @@ func (r Range) Contains(val int) bool {
 func countOverlapse(lines []string, overlapCheck overlapCheckFN) (int, error) {
 
 	var overlapCount int
-	for _, line := range lines {
+	for lineIdx, line := range lines {
 
 		assignments := strings.Split(line, ",")
 		if len(assignments) != 2 {
-			return 0, fmt.Errorf("invalid assigment count '%d' in line '%s'", len(assignments), line)
+			return 0, inputhandler.NewParseError(lineIdx, line, -1, "invalid assigment count '%d'", len(assignments))
 		}
 
 		ass1, err := getAssignemtRange(assignments[0])
 		if err != nil {
-			return 0, fmt.Errorf("invalid assignment '%s' in line '%s'", assignments[0], line)
+			return 0, inputhandler.NewParseError(lineIdx, line, 0, "invalid assignment '%s': %w", assignments[0], err)
 		}
 
 		ass2, err := getAssignemtRange(assignments[1])
 		if err != nil {
-			return 0, fmt.Errorf("invalid assignment '%s' in line '%s'", assignments[1], line)
+			return 0, inputhandler.NewParseError(lineIdx, line, len(assignments[0])+1, "invalid assignment '%s': %w", assignments[1], err)
 		}
 
 		if overlapCheck(*ass1, *ass2) {
@@ -102,17 +119,17 @@ func getAssignemtRange(assignment string) (*Range, error) {
 
 	ranges := strings.Split(assignment, "-")
 	if len(ranges) != 2 {
-		return nil, fmt.Errorf("invalid assignment range '%s'", assignment)
+		return nil, fmt.Errorf("invalid range format")
 	}
 
 	val1, err := strconv.Atoi(ranges[0])
 	if err != nil {
-		return nil, fmt.Errorf("error converting range limit '%s' in assignment '%s'", ranges[0], assignment)
+		return nil, fmt.Errorf("error converting range limit '%s'", ranges[0])
 	}
 
 	val2, err := strconv.Atoi(ranges[1])
 	if err != nil {
-		return nil, fmt.Errorf("error converting range limit '%s' in assignment '%s'", ranges[0], assignment)
+		return nil, fmt.Errorf("error converting range limit '%s'", ranges[1])
 	}
 
 	// noone stated the format of the ranges
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"fmt"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference lists the sections of both elves and compares them one by one,
+// instead of comparing the ends of the ranges.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	var count int
+	for lineIdx, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		var from1, to1, from2, to2 int
+		if _, err := fmt.Sscanf(line, "%d-%d,%d-%d", &from1, &to1, &from2, &to2); err != nil {
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid assignment pair: %w", err)
+		}
+		sections1, sections2 := listSections(from1, to1), listSections(from2, to2)
+
+		shared := 0
+		for section := range sections1 {
+			if sections2[section] {
+				shared++
+			}
+		}
+
+		switch {
+		case part == 1 && (shared == len(sections1) || shared == len(sections2)):
+			count++
+		case part == 2 && shared > 0:
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// listSections returns the sections from one end to the other, in either order
+func listSections(end1, end2 int) map[int]bool {
+
+	if end1 > end2 {
+		end1, end2 = end2, end1
+	}
+
+	sections := make(map[int]bool)
+	for section := end1; section <= end2; section++ {
+		sections[section] = true
+	}
+
+	return sections
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzProcessInput checks that any drawing of the stacks with the moves is processed or rejected, without a panic.
+func FuzzProcessInput(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("[A]\n\nmove 1 from 1 to 2")
+	f.Add("    [B]\n 1   2\n\nmove -1 from 2 to 0\nmove x")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := processInput(lines, false)
+		parsetest.CheckError(t, err)
+
+		_, err = processInput(lines, true)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetGenerator(500, generateSupplyStacks)
+}
+
+// generateSupplyStacks draws 3-9 stacks of crates followed by size moves. The moves never
+// take more crates than there are and leave at least one on every stack, so the top
+// crates can always be read.
+func generateSupplyStacks(rng *rand.Rand, size int) inputhandler.Generated {
+
+	stacks := make([][]byte, 3+rng.Intn(7))
+	maxHeight := 0
+	for idx := range stacks {
+		stacks[idx] = make([]byte, 1+rng.Intn(8))
+		for crateIdx := range stacks[idx] {
+			stacks[idx][crateIdx] = byte('A' + rng.Intn(26))
+		}
+		if len(stacks[idx]) > maxHeight {
+			maxHeight = len(stacks[idx])
+		}
+	}
+
+	lines := make([]string, 0, maxHeight+size+2)
+
+	// the drawing, from the top, every line is as wide as the stacks
+	for level := maxHeight - 1; level >= 0; level-- {
+		cells := make([]string, len(stacks))
+		for idx, stack := range stacks {
+			cells[idx] = "   "
+			if level < len(stack) {
+				cells[idx] = "[" + string(stack[level]) + "]"
+			}
+		}
+		lines = append(lines, strings.Join(cells, " "))
+	}
+	numbers := make([]string, len(stacks))
+	for idx := range numbers {
+		numbers[idx] = fmt.Sprintf(" %d ", idx+1)
+	}
+	lines = append(lines, strings.Join(numbers, " "), "")
+
+	heights := make([]int, len(stacks))
+	for idx, stack := range stacks {
+		heights[idx] = len(stack)
+	}
+
+	for move := 0; move < size; move++ {
+		from := rng.Intn(len(stacks))
+		if heights[from] < 2 {
+			continue
+		}
+		to := rng.Intn(len(stacks) - 1)
+		if to >= from {
+			to++
+		}
+		count := 1 + rng.Intn(heights[from]-1)
+		heights[from] -= count
+		heights[to] += count
+
+		lines = append(lines, fmt.Sprintf("move %d from %d to %d", count, from+1, to+1))
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
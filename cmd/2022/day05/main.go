@@ -2,43 +2,64 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
 	"fmt"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 )
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 5, "Top boxes")
 
-	topBoxesPart1, err := processInput(lines, false)
-	if err != nil {
-		fmt.Printf("Error: while processing input: %v\n", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if results.SolveReference(lines) {
+		results.Print()
+		return
 	}
 
-	topBoxesPart2, err := processInput(lines, true)
-	if err != nil {
-		fmt.Printf("Error: while processing input: %v\n", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(1) {
+		topBoxesPart1, err := processInput(lines, false)
+		if err != nil {
+			outputhandler.PrintError("Error: while processing input", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part1", topBoxesPart1)
 	}
 
-	fmt.Printf("Top boxes - Part1: %s, Part2: %s", topBoxesPart1, topBoxesPart2)
+	if inputhandler.IsPartRequested(2) {
+		topBoxesPart2, err := processInput(lines, true)
+		if err != nil {
+			outputhandler.PrintError("Error: while processing input", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", topBoxesPart2)
+	}
+
+	results.Print()
 }
 
 //-Common----------------------------------------------------------------------
 
 func processInput(lines []string, canDoMultiple bool) (string, error) {
 
+	if len(lines) == 0 {
+		return "", inputhandler.NewParseError(-1, "", -1, "the drawing of the stacks is missing")
+	}
+
 	// build the supply stacks
 	buildMode := true
 
 	stackCount := int(math.Ceil(float64(len(lines[0])) / 4))
 	supply := NewSupplyStacks(stackCount, canDoMultiple)
 
-	for _, line := range lines {
+	for lineIdx, line := range lines {
 
 		if buildMode {
 
@@ -57,7 +78,7 @@ func processInput(lines []string, canDoMultiple bool) (string, error) {
 			// this line has at least one box
 			if strings.Contains(line, "[") {
 
-				for i := 0; i < stackCount; i++ {
+				for i := 0; i < stackCount && 1+i*4 < len(line); i++ {
 					box := rune(line[1+i*4])
 					if box == ' ' {
 						continue
@@ -71,27 +92,29 @@ func processInput(lines []string, canDoMultiple bool) (string, error) {
 
 		arrangementStep := strings.Split(line, " ")
 		if len(arrangementStep) != 6 {
-			return "", fmt.Errorf("invalid arrangement element count '%d' in line '%s'", len(arrangementStep), line)
+			return "", inputhandler.NewParseError(lineIdx, line, -1, "invalid arrangement element count '%d'", len(arrangementStep))
 		}
 
 		moveCount, err := strconv.Atoi(arrangementStep[1])
-		if err != nil {
-			return "", fmt.Errorf("invalid move count '%s' in line '%s'", arrangementStep[1], line)
+		if err != nil || moveCount < 0 {
+			return "", inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 1), "invalid move count '%s'", arrangementStep[1])
 		}
 
 		moveFrom, err := strconv.Atoi(arrangementStep[3])
 		if err != nil {
-			return "", fmt.Errorf("invalid stack reference '%s' in line '%s'", arrangementStep[3], line)
+			return "", inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 3), "invalid stack reference '%s'", arrangementStep[3])
 		}
 
 		moveTo, err := strconv.Atoi(arrangementStep[5])
 		if err != nil {
-			return "", fmt.Errorf("invalid stack reference '%s' in line '%s'", arrangementStep[5], line)
+			return "", inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 5), "invalid stack reference '%s'", arrangementStep[5])
 		}
 
 		move := *NewMove(moveCount, moveFrom, moveTo)
 
-		supply.Rearrange(move)
+		if err := supply.Rearrange(move); err != nil {
+			return "", inputhandler.NewParseError(lineIdx, line, -1, "%w", err)
+		}
 	}
 
 	topBoxes := supply.ReadTopBoxes()
@@ -166,11 +189,11 @@ func NewSupplyStacks(count int, doMultiple bool) *SupplyStacks {
 
 func (ss *SupplyStacks) Rearrange(move Move) error {
 
-	if move.From < 1 || move.From > len(ss.CargoStacks) {
+	if move.From < 1 || move.From >= len(ss.CargoStacks) {
 		return fmt.Errorf("moving from invalid stack '%d' out of '%d'", move.From, len(ss.CargoStacks))
 	}
 
-	if move.To < 1 || move.To > len(ss.CargoStacks) {
+	if move.To < 1 || move.To >= len(ss.CargoStacks) {
 		return fmt.Errorf("moving to invalid stack '%d' out of '%d'", move.To, len(ss.CargoStacks))
 	}
 
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference keeps the stacks as strings with the top box last. The crane of part 1
+// moves the boxes one at a time, the one of part 2 takes them all at once.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	drawingEnd := -1
+	for lineIdx, line := range lines {
+		if len(line) == 0 {
+			drawingEnd = lineIdx
+			break
+		}
+	}
+	if drawingEnd < 1 {
+		return nil, inputhandler.NewParseError(-1, "", -1, "the drawing of the stacks is missing")
+	}
+
+	// the last line of the drawing has the numbers of the stacks
+	stacks := make([]string, len(strings.Fields(lines[drawingEnd-1])))
+	for lineIdx := drawingEnd - 2; lineIdx >= 0; lineIdx-- {
+		for stackIdx := range stacks {
+			if column := 1 + 4*stackIdx; column < len(lines[lineIdx]) && lines[lineIdx][column] != ' ' {
+				stacks[stackIdx] += string(lines[lineIdx][column])
+			}
+		}
+	}
+
+	for lineIdx := drawingEnd + 1; lineIdx < len(lines); lineIdx++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var count, from, to int
+		if _, err := fmt.Sscanf(lines[lineIdx], "move %d from %d to %d", &count, &from, &to); err != nil {
+			return nil, inputhandler.NewParseError(lineIdx, lines[lineIdx], -1, "invalid move: %w", err)
+		}
+		if from < 1 || from > len(stacks) || to < 1 || to > len(stacks) || count > len(stacks[from-1]) {
+			return nil, inputhandler.NewParseError(lineIdx, lines[lineIdx], -1, "impossible move")
+		}
+		from, to = from-1, to-1
+
+		if part == 1 {
+			for moved := 0; moved < count; moved++ {
+				top := len(stacks[from]) - 1
+				stacks[to] += stacks[from][top:]
+				stacks[from] = stacks[from][:top]
+			}
+		} else {
+			bottom := len(stacks[from]) - count
+			boxes := stacks[from][bottom:]
+			stacks[from] = stacks[from][:bottom]
+			stacks[to] += boxes
+		}
+	}
+
+	var topBoxes strings.Builder
+	for _, stack := range stacks {
+		if len(stack) == 0 {
+			topBoxes.WriteByte(' ')
+		} else {
+			topBoxes.WriteByte(stack[len(stack)-1])
+		}
+	}
+
+	return topBoxes.String(), nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzFindSOPMarkerEndIndex checks that the marker is searched in any signal without a panic.
+func FuzzFindSOPMarkerEndIndex(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("aaaa")
+	f.Add("\x00\x00ab\xff")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := findSOPMarkerEndIndex(lines[0], 4)
+		parsetest.CheckError(t, err)
+
+		_, err = findSOPMarkerEndIndex(lines[0], 14)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(4096, generateDatastream)
+}
+
+// generateDatastream creates a datastream of size characters. The noise only uses
+// a few letters, so the start-of-message marker is where it's put.
+func generateDatastream(rng *rand.Rand, size int) inputhandler.Generated {
+
+	if size < 14 {
+		size = 14
+	}
+
+	stream := make([]byte, size)
+	for idx := range stream {
+		stream[idx] = byte('a' + rng.Intn(6))
+	}
+
+	marker := rng.Perm(26)[:14]
+	markerIdx := rng.Intn(size - 13)
+	for idx, letter := range marker {
+		stream[markerIdx+idx] = byte('a' + letter)
+	}
+
+	return inputhandler.Generated{Lines: []string{string(stream)}}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"fmt"
+)
+
+func main() {
+
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
+	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 6, "SOP marker end index")
+
+	if len(lines) == 0 || len(lines[0]) == 0 {
+		outputhandler.PrintError("Error", inputhandler.NewParseError(-1, "", -1, "the signal is missing"))
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	if inputhandler.IsPartRequested(1) {
+		sopMarkerEndIdxPart1, err := findSOPMarkerEndIndex(lines[0], 4)
+		if err != nil {
+			outputhandler.PrintError(fmt.Sprintf("Error: while searching for %d long marker", 4), err)
+		}
+
+		results.Add("Part1", sopMarkerEndIdxPart1)
+	}
+
+	if inputhandler.IsPartRequested(2) {
+		sopMarkerEndIdxPart2, err := findSOPMarkerEndIndex(lines[0], 14)
+		if err != nil {
+			outputhandler.PrintError(fmt.Sprintf("Error: while searching for %d long marker", 14), err)
+		}
+
+		results.Add("Part2", sopMarkerEndIdxPart2)
+	}
+
+	results.Print()
+}
+
+func findSOPMarkerEndIndex(signal string, markerSize int) (int, error) {
+
+	markerBuff := make([]rune, markerSize)
+	currBufferIdx := 0
+	for charIdx, char := range signal {
+
+		// check if in last 4
+		for markerIdx, markerChar := range markerBuff {
+			if char == markerChar {
+				// reset and copy the rest from the match
+				tempBuff := make([]rune, markerSize)
+				j := 0
+				for i := markerIdx + 1; i < markerSize; i++ {
+					if markerBuff[i] == 0 {
+						continue
+					}
+					tempBuff[j] = markerBuff[i]
+					j++
+				}
+				markerBuff = tempBuff
+				currBufferIdx = j
+			}
+		}
+
+		// new one, insert
+		markerBuff[currBufferIdx] = char
+		currBufferIdx++
+
+		// found it!
+		if currBufferIdx == markerSize {
+			return charIdx + 1, nil // zero based
+		}
+	}
+
+	return 0, inputhandler.NewParseError(0, signal, -1, "marker not found")
+}
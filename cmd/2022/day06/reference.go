@@ -0,0 +1,37 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference checks every window of the signal for a repeated character from
+// scratch, instead of keeping a buffer of the last distinct ones.
+func solveReference(_ context.Context, lines []string, part int) (interface{}, error) {
+
+	if len(lines) == 0 || len(lines[0]) == 0 {
+		return nil, inputhandler.NewParseError(-1, "", -1, "the signal is missing")
+	}
+	signal := lines[0]
+
+	markerSize := 4
+	if part == 2 {
+		markerSize = 14
+	}
+
+	for end := markerSize; end <= len(signal); end++ {
+		seen := make(map[byte]bool)
+		for idx := end - markerSize; idx < end; idx++ {
+			seen[signal[idx]] = true
+		}
+		if len(seen) == markerSize {
+			return end, nil
+		}
+	}
+
+	return nil, inputhandler.NewParseError(0, signal, -1, "marker not found")
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseFilesystem checks that any terminal output is parsed or rejected, without a panic.
+func FuzzParseFilesystem(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("$\n$ cd\n$ cd ..")
+	f.Add("$ ls\ndir\n12 a b\nx y")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := parseFilesystem(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+	"strconv"
+)
+
+func init() {
+	inputhandler.SetGenerator(400, generateTerminalOutput)
+}
+
+type generatedDir struct {
+	name  string
+	files []generatedFile
+	dirs  []*generatedDir
+}
+
+type generatedFile struct {
+	name string
+	size int
+}
+
+// generateTerminalOutput creates a filesystem with size files and browses it with cd and ls.
+// The disk is between 40M and 69M full, so part 2 has something to delete.
+func generateTerminalOutput(rng *rand.Rand, size int) inputhandler.Generated {
+
+	root := &generatedDir{name: "/"}
+	dirs := []*generatedDir{root}
+	weights := make([]int, 0, size)
+	totalWeight := 0
+	for fileIdx := 0; fileIdx < size; fileIdx++ {
+
+		dir := dirs[rng.Intn(len(dirs))]
+		if rng.Intn(4) == 0 {
+			child := &generatedDir{name: uniqueName(rng, dir, "")}
+			dir.dirs = append(dir.dirs, child)
+			dirs = append(dirs, child)
+			dir = child
+		}
+
+		extension := ""
+		if rng.Intn(2) == 0 {
+			extension = "." + randomName(rng, 3)
+		}
+		weight := 1 + rng.Intn(1000)
+		dir.files = append(dir.files, generatedFile{name: uniqueName(rng, dir, extension), size: weight})
+		weights = append(weights, weight)
+		totalWeight += weight
+	}
+
+	// scale the sizes to fill the disk, the rounding only makes it smaller
+	used := 40000001 + rng.Intn(29000000)
+	for _, dir := range dirs {
+		for idx := range dir.files {
+			dir.files[idx].size = 1 + dir.files[idx].size*(used/totalWeight-1)
+		}
+	}
+
+	lines := []string{"$ cd /"}
+	lines = browseDir(rng, root, lines)
+
+	return inputhandler.Generated{Lines: lines}
+}
+
+// browseDir lists the directory and visits its subdirectories in a random order
+func browseDir(rng *rand.Rand, dir *generatedDir, lines []string) []string {
+
+	lines = append(lines, "$ ls")
+	listing := make([]string, 0, len(dir.dirs)+len(dir.files))
+	for _, child := range dir.dirs {
+		listing = append(listing, "dir "+child.name)
+	}
+	for _, file := range dir.files {
+		listing = append(listing, strconv.Itoa(file.size)+" "+file.name)
+	}
+	rng.Shuffle(len(listing), func(i, j int) { listing[i], listing[j] = listing[j], listing[i] })
+	lines = append(lines, listing...)
+
+	for _, childIdx := range rng.Perm(len(dir.dirs)) {
+		lines = append(lines, "$ cd "+dir.dirs[childIdx].name)
+		lines = browseDir(rng, dir.dirs[childIdx], lines)
+		lines = append(lines, "$ cd ..")
+	}
+
+	return lines
+}
+
+func uniqueName(rng *rand.Rand, dir *generatedDir, extension string) string {
+	for {
+		name := randomName(rng, 1+rng.Intn(8)) + extension
+		if !hasName(dir, name) {
+			return name
+		}
+	}
+}
+
+func hasName(dir *generatedDir, name string) bool {
+	for _, child := range dir.dirs {
+		if child.name == name {
+			return true
+		}
+	}
+	for _, file := range dir.files {
+		if file.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func randomName(rng *rand.Rand, length int) string {
+	name := make([]byte, length)
+	for idx := range name {
+		name[idx] = byte('a' + rng.Intn(26))
+	}
+	return string(name)
+}
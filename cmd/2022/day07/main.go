@@ -0,0 +1,300 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const treeColor = outputhandler.White
+const directoryColor = outputhandler.BrightCyan
+const fileColor = outputhandler.BrightGreen
+const sizeColor = outputhandler.BrightMagenta
+
+func main() {
+
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
+	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 7, "Result")
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	rootNode, err := parseFilesystem(lines)
+	if err != nil {
+		outputhandler.PrintError("Error: while parsing filesystem", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+	visualizeFileSystem(rootNode) // had to nerd it, not sorry :)
+
+	updateFolderSizes(rootNode)
+	if path := outputhandler.GetSVGPath(); len(path) > 0 {
+		if err := exportFileSystemSVG(rootNode, path); err != nil {
+			outputhandler.PrintError("Warning", err)
+		}
+	}
+
+	// Part 1
+	if inputhandler.IsPartRequested(1) {
+		const part1SizeLimit = 100000
+		var sizeAtMost = func(node *Node) bool {
+			return node.Size <= part1SizeLimit
+		}
+		foundDirs := getDirsWithCondition(rootNode, sizeAtMost)
+
+		var part1SumSizes int
+		for _, dir := range foundDirs {
+			part1SumSizes += dir.Size
+		}
+
+		results.Add("Part1", part1SumSizes)
+	}
+
+	// Part 2
+	if inputhandler.IsPartRequested(2) {
+		const totalAvailableSpace = 70000000
+		const neededFreeSpace = 30000000
+		haveFreeSpace := (totalAvailableSpace - rootNode.Size)
+		extraSpaceNeeded := neededFreeSpace - haveFreeSpace
+
+		var part2SizeToDelete int
+		if extraSpaceNeeded <= 0 {
+			part2SizeToDelete = 0 // already have enough
+		} else {
+
+			var sizeGraterThan = func(node *Node) bool {
+				return node.Size > extraSpaceNeeded
+			}
+			foundDirs := getDirsWithCondition(rootNode, sizeGraterThan)
+
+			sort.Slice(foundDirs, func(i int, j int) bool {
+				return foundDirs[i].Size < foundDirs[j].Size
+			})
+
+			part2SizeToDelete = foundDirs[0].Size
+		}
+
+		results.Add("Part2", part2SizeToDelete)
+	}
+
+	results.Print()
+}
+
+func parseFilesystem(lines []string) (*Node, error) {
+
+	var rootNode = NewNode(Directory, "/", 0)
+	var currNode = rootNode // just to be safe
+
+	var currCommand string
+	var currCmdArgs []string
+	for lineIdx, line := range lines {
+
+		if len(line) == 0 {
+			continue
+		}
+
+		// we have a prompt
+		var isPrompt bool
+		if line[:1] == "$" {
+			isPrompt = true
+
+			tokens := strings.Split(line, " ")
+			if len(tokens) < 2 {
+				return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid command")
+			}
+			currCommand = tokens[1]
+			currCmdArgs = tokens[2:]
+		}
+
+		// process command for this line
+		switch currCommand {
+		case "cd":
+
+			if len(currCmdArgs) < 1 {
+				return nil, inputhandler.NewParseError(lineIdx, line, -1, "too few arguments for 'cd'")
+			}
+
+			switch currCmdArgs[0] {
+			case "/":
+				currNode = rootNode
+
+			case "..":
+				if currNode.Parent == nil {
+					return nil, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 2), "no parent directory of '%s'", currNode.Name)
+				}
+				currNode = currNode.Parent
+
+			default:
+				var found = false
+				for idx, node := range currNode.ChildNodes {
+					if currCmdArgs[0] == node.Name {
+						currNode = currNode.ChildNodes[idx]
+						found = true
+						break
+					}
+				}
+
+				if !found {
+					return nil, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 2), "invalid child directory referenced '%s'", currCmdArgs[0])
+				}
+			}
+
+		case "ls":
+			if !isPrompt { // we need only the data
+
+				tokens := strings.Split(line, " ")
+				if len(tokens) < 2 {
+					return nil, inputhandler.NewParseError(lineIdx, line, -1, "too few elements for directory listing")
+				}
+
+				var node *Node
+				if tokens[0] == "dir" {
+					node = NewNode(Directory, tokens[1], 0)
+				} else {
+					size, err := strconv.Atoi(tokens[0])
+					if err != nil || size < 0 {
+						return nil, inputhandler.NewParseError(lineIdx, line, 0, "couldn't parse file size '%s'", tokens[0])
+					}
+					node = NewNode(File, tokens[1], size)
+				}
+
+				currNode.AddNode(node)
+			}
+
+		default:
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "unknown command '%s'", currCommand)
+		}
+	}
+
+	return rootNode, nil
+}
+
+func visualizeFileSystem(node *Node) {
+	outputhandler.PrintTree(node, outputhandler.TreeOptions[*Node]{
+		TreeColor: treeColor,
+		Color: func(node *Node) outputhandler.TerminalColor {
+			if node.Type == File {
+				return fileColor
+			}
+			return directoryColor
+		},
+		Annotate: func(node *Node) (string, outputhandler.TerminalColor) {
+			if node.Type == File {
+				return strconv.Itoa(node.Size), sizeColor
+			}
+			return "", sizeColor
+		},
+	})
+}
+
+// exportFileSystemSVG writes the tree into an SVG file, sizes are only correct
+// after updateFolderSizes() was called.
+func exportFileSystemSVG(node *Node, path string) error {
+	svg := outputhandler.NewSVGTree(exportFileSystemSVG_recurse(node))
+	return outputhandler.WriteSVGFile(path, svg)
+}
+
+func exportFileSystemSVG_recurse(node *Node) *outputhandler.SVGTreeNode {
+
+	svgNode := &outputhandler.SVGTreeNode{
+		Label:    node.Name,
+		Size:     node.Size,
+		Children: make([]*outputhandler.SVGTreeNode, 0, len(node.ChildNodes)),
+	}
+
+	switch node.Type {
+	case File:
+		svgNode.Color = outputhandler.CSSColor(fileColor)
+
+	case Directory:
+		svgNode.Color = outputhandler.CSSColor(directoryColor)
+		for childIdx := range node.ChildNodes {
+			svgNode.Children = append(svgNode.Children, exportFileSystemSVG_recurse(node.ChildNodes[childIdx]))
+		}
+	}
+
+	return svgNode
+}
+
+func updateFolderSizes(node *Node) int {
+
+	var size int
+	for idx, chn := range node.ChildNodes {
+		if chn.Type == Directory {
+			size += updateFolderSizes(node.ChildNodes[idx])
+		} else {
+
+			size += chn.Size
+
+		}
+	}
+	node.Size = size
+	return size
+}
+
+type conditionFunc func(*Node) bool
+
+func getDirsWithCondition(node *Node, condition conditionFunc) []*Node {
+	return getDirsWithCondition_recurse(node, condition, make([]*Node, 0))
+}
+
+func getDirsWithCondition_recurse(node *Node, condition conditionFunc, dirs []*Node) []*Node {
+
+	for childIdx, child := range node.ChildNodes {
+		if child.Type == Directory {
+			dirs = getDirsWithCondition_recurse(node.ChildNodes[childIdx], condition, dirs)
+		}
+	}
+
+	if condition(node) {
+		return append(dirs, node)
+	}
+
+	return dirs
+}
+
+//-----------------------------------------------------------------------------
+
+type NodeType int
+
+const (
+	Directory NodeType = 1
+	File      NodeType = 2
+)
+
+type Node struct {
+	Type       NodeType
+	Name       string
+	Size       int
+	ChildNodes []*Node
+	Parent     *Node
+}
+
+func NewNode(nodeType NodeType, name string, size int) *Node {
+	return &Node{
+		Type:       nodeType,
+		Name:       name,
+		Size:       size,
+		ChildNodes: make([]*Node, 0, 10),
+		Parent:     nil,
+	}
+}
+
+func (n *Node) Label() string {
+	return n.Name
+}
+
+func (n *Node) Children() []*Node {
+	return n.ChildNodes
+}
+
+func (n *Node) AddNode(node *Node) {
+	node.Parent = n
+	n.ChildNodes = append(n.ChildNodes, node)
+}
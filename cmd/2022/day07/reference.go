@@ -0,0 +1,85 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference keeps the files by their full path, no tree. The size of a directory
+// is the sum of the files whose path starts with the directory's.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	files := make(map[string]int)
+	dirs := map[string]bool{"/": true}
+	cwd := "/"
+	for lineIdx, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0 || line == "$ ls":
+		case len(fields) == 3 && fields[0] == "$" && fields[1] == "cd":
+			switch fields[2] {
+			case "/":
+				cwd = "/"
+			case "..":
+				if cwd == "/" {
+					return nil, inputhandler.NewParseError(lineIdx, line, -1, "no parent directory of '/'")
+				}
+				cwd = cwd[:strings.LastIndex(strings.TrimSuffix(cwd, "/"), "/")+1]
+			default:
+				cwd += fields[2] + "/"
+			}
+			dirs[cwd] = true
+		case len(fields) == 2 && fields[0] == "dir":
+			dirs[cwd+fields[1]+"/"] = true
+		case len(fields) == 2:
+			size, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, inputhandler.NewParseError(lineIdx, line, 0, "invalid file size '%s'", fields[0])
+			}
+			files[cwd+fields[1]] = size
+		default:
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "unknown line")
+		}
+	}
+
+	dirSizes := make(map[string]int)
+	for dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for path, size := range files {
+			if strings.HasPrefix(path, dir) {
+				dirSizes[dir] += size
+			}
+		}
+	}
+
+	if part == 1 {
+		var sum int
+		for _, size := range dirSizes {
+			if size <= 100000 {
+				sum += size
+			}
+		}
+		return sum, nil
+	}
+
+	needed := 30000000 - (70000000 - dirSizes["/"])
+	if needed <= 0 {
+		return 0, nil
+	}
+	smallest := dirSizes["/"]
+	for _, size := range dirSizes {
+		if size >= needed && size < smallest {
+			smallest = size
+		}
+	}
+
+	return smallest, nil
+}
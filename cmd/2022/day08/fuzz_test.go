@@ -0,0 +1,29 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzValidateForest checks that any forest is accepted or rejected, without a panic.
+func FuzzValidateForest(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("12\n3")
+	f.Add("1a\n00")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		if err := validateForest(lines); err != nil {
+			parsetest.CheckError(t, err)
+			return
+		}
+
+		for hIdx := 0; hIdx < len(lines[0]); hIdx++ {
+			for vIdx := 0; vIdx < len(lines); vIdx++ {
+				checkTree(hIdx, vIdx, lines)
+			}
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(99, generateForest)
+}
+
+// generateForest creates a size x size grid of tree heights
+func generateForest(rng *rand.Rand, size int) inputhandler.Generated {
+
+	lines := make([]string, size)
+	for rowIdx := range lines {
+		row := make([]byte, size)
+		for colIdx := range row {
+			row[colIdx] = byte('0' + rng.Intn(10))
+		}
+		lines[rowIdx] = string(row)
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
@@ -2,12 +2,26 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
-	"fmt"
+	"AoC22/internal/outputhandler"
 )
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 8, "Result")
+
+	if err := validateForest(lines); err != nil {
+		outputhandler.PrintError("Error: while reading the forest", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
 
 	var visibleCount int
 	var highestScenicScore int
@@ -34,7 +48,36 @@ func main() {
 		}
 	}
 
-	fmt.Printf("Result - Part1: %d, Part2: %d\n", visibleCount, highestScenicScore)
+	// both parts come from the same pass, only the answers are selected
+	if inputhandler.IsPartRequested(1) {
+		results.Add("Part1", visibleCount)
+	}
+	if inputhandler.IsPartRequested(2) {
+		results.Add("Part2", highestScenicScore)
+	}
+
+	results.Print()
+}
+
+// validateForest checks that the forest is a rectangle of tree heights
+func validateForest(forest []string) error {
+
+	if len(forest) == 0 || len(forest[0]) == 0 {
+		return inputhandler.NewParseError(-1, "", -1, "the forest is empty")
+	}
+
+	for vIdx, line := range forest {
+		if len(line) != len(forest[0]) {
+			return inputhandler.NewParseError(vIdx, line, -1, "invalid row length '%d', expected '%d'", len(line), len(forest[0]))
+		}
+		for hIdx, tree := range line {
+			if tree < '0' || tree > '9' {
+				return inputhandler.NewParseError(vIdx, line, hIdx, "invalid tree height '%c'", tree)
+			}
+		}
+	}
+
+	return nil
 }
 
 func checkTree(hIdx, vIdx int, forest []string) (bool, int) {
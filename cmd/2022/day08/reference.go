@@ -0,0 +1,54 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference walks from every tree to the edge in the four directions with one
+// loop, instead of a function per side with its own edge cases.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	if err := validateForest(lines); err != nil {
+		return nil, err
+	}
+
+	var visibleCount, highestScore int
+	for y := range lines {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for x := range lines[y] {
+			visible, score := false, 1
+			for _, direction := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+				distance, blocked := 0, false
+				for nx, ny := x+direction[0], y+direction[1]; ny >= 0 && ny < len(lines) && nx >= 0 && nx < len(lines[ny]); nx, ny = nx+direction[0], ny+direction[1] {
+					distance++
+					if lines[ny][nx] >= lines[y][x] {
+						blocked = true
+						break
+					}
+				}
+				visible = visible || !blocked
+				score *= distance
+			}
+
+			if visible {
+				visibleCount++
+			}
+			if score > highestScore {
+				highestScore = score
+			}
+		}
+	}
+
+	if part == 1 {
+		return visibleCount, nil
+	}
+	return highestScore, nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseMoves checks that any list of moves is parsed or rejected, without a panic.
+func FuzzParseMoves(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("R 4\nU")
+	f.Add("L -1\nX 2\nD 1 2")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := parseMoves(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(2000, generateMotions)
+}
+
+// generateMotions creates size motions of the head
+func generateMotions(rng *rand.Rand, size int) inputhandler.Generated {
+
+	lines := make([]string, size)
+	for idx := range lines {
+		lines[idx] = fmt.Sprintf("%c %d", "RULD"[rng.Intn(4)], 1+rng.Intn(19))
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
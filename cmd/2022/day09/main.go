@@ -5,7 +5,6 @@ import (
 	"AoC22/internal/outputhandler"
 	"fmt"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 )
@@ -21,47 +20,99 @@ func main() {
 	startColor = outputhandler.GetColor(outputhandler.White, outputhandler.BrightRed)
 
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 9, "Result")
 
-	tailTrackCountPart1, err := simulate(lines, 2)
+	moves, err := parseMoves(lines)
 	if err != nil {
-		fmt.Printf("Error: simulating movement: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		outputhandler.PrintError("Error: reading the moves", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
 	}
 
-	tailTrackCountPart2, err := simulate(lines, 10)
-	if err != nil {
-		fmt.Printf("Error: simulating movement: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if results.SolveReference(lines) {
+		results.Print()
+		return
 	}
 
-	fmt.Printf("Result - Part1: %d, Part2: %d", tailTrackCountPart1, tailTrackCountPart2)
-}
+	var lastBridge *RopeBridge // exported with -svg, the rope of part 2 if both are solved
 
-func simulate(lines []string, knots int) (int, error) {
+	if inputhandler.IsPartRequested(1) {
+		bridgePart1, err := simulate(moves, 2)
+		if err != nil {
+			outputhandler.PrintError("Error: simulating movement", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
 
-	if knots < 2 {
-		return 0, fmt.Errorf("invalid number of knots '%d' need at least 2", knots)
+		results.Add("Part1", len(bridgePart1.TailTrack))
+		lastBridge = bridgePart1
 	}
 
-	bridge := NewRopeBridge(knots)
+	if inputhandler.IsPartRequested(2) {
+		bridgePart2, err := simulate(moves, 10)
+		if err != nil {
+			outputhandler.PrintError("Error: simulating movement", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", len(bridgePart2.TailTrack))
+		lastBridge = bridgePart2
+	}
+
+	if path := outputhandler.GetSVGPath(); len(path) > 0 && lastBridge != nil {
+		if err := ExportBridgeSVG(*lastBridge, path); err != nil {
+			outputhandler.PrintError("Warning", err)
+		}
+	}
+
+	results.Print()
+}
 
+// Move is a line of the input, the steps of the head in one direction
+type Move struct {
+	Direction string
+	Steps     int
+}
+
+func parseMoves(lines []string) ([]Move, error) {
+
+	moves := make([]Move, 0, len(lines))
 	for lineIdx, line := range lines {
-		_ = lineIdx
 
 		tokens := strings.Split(line, " ")
 		if len(tokens) != 2 {
-			return 0, fmt.Errorf("invalid number of inputs in line '%s'", line)
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid number of inputs '%d'", len(tokens))
 		}
 
 		direction := tokens[0]
+		switch direction {
+		case "L", "R", "U", "D":
+		default:
+			return nil, inputhandler.NewParseError(lineIdx, line, 0, "invalid movement '%s'", tokens[0])
+		}
+
 		steps, err := strconv.Atoi(tokens[1])
-		if err != nil {
-			return 0, fmt.Errorf("invalid steps '%s' in line '%s'", tokens[1], line)
+		if err != nil || steps < 0 {
+			return nil, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 1), "invalid steps '%s'", tokens[1])
 		}
 
-		for currStep := 1; currStep <= steps; currStep++ {
+		moves = append(moves, Move{Direction: direction, Steps: steps})
+	}
+
+	return moves, nil
+}
+
+// simulate moves the rope of the knots, the tail track is in the returned bridge
+func simulate(moves []Move, knots int) (*RopeBridge, error) {
+
+	if knots < 2 {
+		return nil, fmt.Errorf("invalid number of knots '%d' need at least 2", knots)
+	}
+
+	bridge := NewRopeBridge(knots)
+
+	for _, move := range moves {
+		for currStep := 1; currStep <= move.Steps; currStep++ {
 
-			switch direction {
+			switch move.Direction {
 			case "L":
 				bridge.MoveLeft()
 
@@ -75,7 +126,7 @@ func simulate(lines []string, knots int) (int, error) {
 				bridge.MoveDown()
 
 			default:
-				return 0, fmt.Errorf("invalid movement '%s' in line '%s'", tokens[0], line)
+				return nil, fmt.Errorf("invalid movement '%s'", move.Direction)
 
 			}
 
@@ -87,7 +138,7 @@ func simulate(lines []string, knots int) (int, error) {
 
 	//VisualizeTailTracks(bridge.TailTrack)
 
-	return len(bridge.TailTrack), nil
+	return bridge, nil
 }
 
 //-----------------------------------------------------------------------------
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference moves the knots as plain coordinates, a knot that's too far from
+// the one before steps one towards it on both axes. No drag directions.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	moves, err := parseMoves(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	knotCount := 2
+	if part == 2 {
+		knotCount = 10
+	}
+	knots := make([][2]int, knotCount)
+	visited := map[[2]int]bool{knots[knotCount-1]: true}
+
+	steps := map[string][2]int{"L": {-1, 0}, "R": {1, 0}, "U": {0, -1}, "D": {0, 1}}
+	for _, move := range moves {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for step := 0; step < move.Steps; step++ {
+			knots[0][0] += steps[move.Direction][0]
+			knots[0][1] += steps[move.Direction][1]
+
+			for knotIdx := 1; knotIdx < knotCount; knotIdx++ {
+				dx, dy := knots[knotIdx-1][0]-knots[knotIdx][0], knots[knotIdx-1][1]-knots[knotIdx][1]
+				if dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1 {
+					break
+				}
+				knots[knotIdx][0] += sign(dx)
+				knots[knotIdx][1] += sign(dy)
+			}
+			visited[knots[knotCount-1]] = true
+		}
+	}
+
+	return len(visited), nil
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
@@ -38,9 +38,27 @@ func VisualizeBridge(bridge RopeBridge) {
 
 // VisualizeTailTracks prints out the field containing the tailtrack points to stdout.
 // It uses the above specified colors if set.
+// The start is not marked when drawing with higher density.
 func VisualizeTailTracks(tracks []Position) {
 
 	offX, offY, maxX, maxY := getDimensions(tracks)
+
+	if density := outputhandler.GetRequestedDensity(); density != outputhandler.DensityNormal {
+		grid := make([][]bool, maxY+1)
+		for rowIdx := range grid {
+			grid[rowIdx] = make([]bool, maxX+1)
+		}
+		for _, pos := range tracks {
+			grid[pos.Y+offY][pos.X+offX] = true
+		}
+
+		for _, line := range outputhandler.RenderGrid(grid, density) {
+			fmt.Println(tailMarkColor + line + outputhandler.GetReset())
+		}
+		fmt.Println()
+		return
+	}
+
 	field := createField(maxX+1, maxY+1)
 
 	for _, pos := range tracks {
@@ -68,6 +86,40 @@ func VisualizeKnots(knots []Position) {
 	colorPrint(field)
 }
 
+// ExportBridgeSVG writes the tailtrack with the knots of the bridge as a line
+// on top into an SVG file.
+func ExportBridgeSVG(bridge RopeBridge, path string) error {
+
+	combined := append([]Position{}, bridge.TailTrack...)
+	combined = append(combined, bridge.Knots...)
+	offX, offY, maxX, maxY := getDimensions(combined)
+
+	visited := make(map[Position]bool, len(bridge.TailTrack))
+	for _, pos := range bridge.TailTrack {
+		visited[pos] = true
+	}
+
+	svg := outputhandler.NewSVGGrid(maxX+1, maxY+1)
+	svg.CellColor = func(x, y int) string {
+		pos := Position{X: x - offX, Y: y - offY}
+		if pos == bridge.TailTrack[0] {
+			return outputhandler.CSSColor(outputhandler.BrightRed)
+		}
+		if visited[pos] {
+			return outputhandler.CSSColor(outputhandler.Cyan)
+		}
+		return ""
+	}
+
+	knots := make([]outputhandler.SVGPoint, len(bridge.Knots))
+	for idx, pos := range bridge.Knots {
+		knots[idx] = outputhandler.SVGPoint{X: pos.X + offX, Y: pos.Y + offY}
+	}
+	svg.AddPolyline(knots, outputhandler.CSSColor(outputhandler.BrightGreen), "rope")
+
+	return outputhandler.WriteSVGFile(path, svg)
+}
+
 func colorPrint(field [][]byte) {
 
 	for _, row := range field {
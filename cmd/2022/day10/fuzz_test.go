@@ -0,0 +1,30 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"errors"
+	"testing"
+)
+
+// FuzzRunCode checks that any program runs to its end or is rejected, without a panic.
+func FuzzRunCode(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("noop\naddx\naddx x")
+	f.Add("addx -99999\nnoop 1 2\njmp 1")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		err := runCode(lines, NewSignalStrengthProbe([]int{20, 60, 100, 140, 180, 220}))
+		if !errors.Is(err, ErrorEndOfProgram) {
+			parsetest.CheckError(t, err)
+		}
+
+		err = runCode(lines, NewDisplaySignalProbe([]int{40, 80, 120, 160, 200, 240}))
+		if !errors.Is(err, ErrorEndOfProgram) {
+			parsetest.CheckError(t, err)
+		}
+	})
+}
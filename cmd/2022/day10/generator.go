@@ -0,0 +1,43 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+	"strconv"
+)
+
+func init() {
+	inputhandler.SetGenerator(240, generateProgram)
+}
+
+// generateProgram creates a program that runs for at least size cycles, but at least
+// the 240 of the CRT. The X register stays around the screen.
+func generateProgram(rng *rand.Rand, size int) inputhandler.Generated {
+
+	if size < 240 {
+		size = 240
+	}
+
+	lines := make([]string, 0, size)
+	x := 1
+	for cycles := 0; cycles < size; {
+		if rng.Intn(3) == 0 {
+			lines = append(lines, "noop")
+			cycles++
+			continue
+		}
+
+		value := rng.Intn(31) - 15
+		if x+value < -2 || x+value > 42 {
+			value = -value
+		}
+		if value == 0 {
+			value = 1
+		}
+		x += value
+		lines = append(lines, "addx "+strconv.Itoa(value))
+		cycles += 2
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
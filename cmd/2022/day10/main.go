@@ -5,47 +5,68 @@ import (
 	"AoC22/internal/outputhandler"
 	"errors"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 )
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 10, "Result")
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
 
 	// Part 1
-	part1ProbeCycles := []int{20, 60, 100, 140, 180, 220}
-	part1Probe := NewSignalStrengthProbe(part1ProbeCycles)
+	if inputhandler.IsPartRequested(1) {
+		part1ProbeCycles := []int{20, 60, 100, 140, 180, 220}
+		part1Probe := NewSignalStrengthProbe(part1ProbeCycles)
+
+		err := runCode(lines, part1Probe)
+		if err != nil && !errors.Is(err, ErrorEndOfProgram) {
+			outputhandler.PrintError("Error while running part 1 code", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
 
-	err := runCode(lines, part1Probe)
-	if err != nil && !errors.Is(err, ErrorEndOfProgram) {
-		fmt.Printf("Error while running part 1 code: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		results.Add("Part1", part1Probe.SumSignalStrength)
 	}
 
 	// Part 2
-	part2ProbeCycles := []int{40, 80, 120, 160, 200, 240}
-	part2Probe := NewDisplaySignalProbe(part2ProbeCycles)
+	if inputhandler.IsPartRequested(2) {
+		part2ProbeCycles := []int{40, 80, 120, 160, 200, 240}
+		part2Probe := NewDisplaySignalProbe(part2ProbeCycles)
+
+		err := runCode(lines, part2Probe)
+		if err != nil && !errors.Is(err, ErrorEndOfProgram) {
+			outputhandler.PrintError("Error while running part 2 code", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
 
-	err = runCode(lines, part2Probe)
-	if err != nil && !errors.Is(err, ErrorEndOfProgram) {
-		fmt.Printf("Error while running part 2 code: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		vizualizeDisplaySignalProbe(part2Probe)
+		results.Add("Part2", strings.Join(part2Probe.Display, "\n"))
 	}
 
-	fmt.Printf("Result - Part1: %d, Part 2:\n", part1Probe.SumSignalStrength)
-	vizualizeDisplaySignalProbe(part2Probe)
+	results.Print()
 }
 
 func vizualizeDisplaySignalProbe(probe *DisplaySignalProbe) {
 
-	outputhandler.Initialize()
-	defer outputhandler.Reset()
-
 	litPixelColor := outputhandler.GetColor(outputhandler.White, outputhandler.BrightGreen)
 	unlitPixelColor := outputhandler.GetForeground(outputhandler.Gray)
 
+	if density := outputhandler.GetRequestedDensity(); density != outputhandler.DensityNormal {
+		grid := outputhandler.GridFromStrings(probe.Display, "#")
+		for _, line := range outputhandler.RenderGrid(grid, density) {
+			fmt.Println(outputhandler.GetForeground(outputhandler.BrightGreen) + line + outputhandler.GetReset())
+		}
+		return
+	}
+
 	for _, row := range probe.Display {
 
 		var colorized string = ""
@@ -199,6 +220,7 @@ type CPU struct {
 
 	Instruction    string
 	InstArgs       []string
+	InstLine       string // for the error messages
 	InstCyclesLeft int
 
 	databus *DataBus
@@ -224,12 +246,13 @@ func (cpu *CPU) Tick() error {
 		}
 
 		tokens := strings.Split(line, " ")
-		if len(tokens) == 0 {
-			return fmt.Errorf("missing instruction at line number %d", cpu.ProgramCounter+1)
+		if len(tokens[0]) == 0 {
+			return inputhandler.NewParseError(cpu.ProgramCounter, line, -1, "missing instruction")
 		}
 
 		cpu.Instruction = tokens[0]
 		cpu.InstArgs = tokens[1:]
+		cpu.InstLine = line
 
 		switch cpu.Instruction {
 		case "noop":
@@ -237,12 +260,12 @@ func (cpu *CPU) Tick() error {
 
 		case "addx":
 			if len(cpu.InstArgs) < 1 {
-				return fmt.Errorf("not enough arguments for addx in line '%s'", line)
+				return inputhandler.NewParseError(cpu.ProgramCounter, line, -1, "not enough arguments for addx")
 			}
 			cpu.InstCyclesLeft = 2
 
 		default:
-			return fmt.Errorf("unknown command in line '%s'", line)
+			return inputhandler.NewParseError(cpu.ProgramCounter, line, 0, "unknown command '%s'", cpu.Instruction)
 		}
 
 	}
@@ -257,7 +280,7 @@ func (cpu *CPU) Tick() error {
 		if cpu.InstCyclesLeft == 0 {
 			val, err := strconv.Atoi(cpu.InstArgs[0])
 			if err != nil {
-				return fmt.Errorf("invalid argument for addx '%s' in line number '%d'", cpu.InstArgs[0], cpu.ProgramCounter+1)
+				return inputhandler.NewParseError(cpu.ProgramCounter, cpu.InstLine, inputhandler.FieldColumn(cpu.InstLine, 1), "invalid argument for addx '%s'", cpu.InstArgs[0])
 			}
 
 			cpu.RegX += val
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference lists the value of X during every cycle first, then reads the signal
+// strengths and the pixels from the list. No CPU, GPU or probes.
+func solveReference(_ context.Context, lines []string, part int) (interface{}, error) {
+
+	x := 1
+	during := []int{0} // by cycle, from 1
+	for lineIdx, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && fields[0] == "noop":
+			during = append(during, x)
+		case len(fields) == 2 && fields[0] == "addx":
+			value, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 1), "invalid argument for addx '%s'", fields[1])
+			}
+			during = append(during, x, x)
+			x += value
+		default:
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid instruction")
+		}
+	}
+
+	if part == 1 {
+		var sum int
+		for cycle := 20; cycle <= 220 && cycle < len(during); cycle += 40 {
+			sum += cycle * during[cycle]
+		}
+		return sum, nil
+	}
+
+	rows := make([]string, 0, 6)
+	for rowStart := 1; rowStart+39 < len(during) && len(rows) < 6; rowStart += 40 {
+		var row strings.Builder
+		for column := 0; column < 40; column++ {
+			if spriteX := during[rowStart+column]; spriteX-1 <= column && column <= spriteX+1 {
+				row.WriteByte('#')
+			} else {
+				row.WriteByte('.')
+			}
+		}
+		rows = append(rows, row.String())
+	}
+
+	return strings.Join(rows, "\n"), nil
+}
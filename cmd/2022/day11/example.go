@@ -0,0 +1,15 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+)
+
+// the monkeys are not read from an input, see CreateExampleMonkeyGroupModulo()
+func init() {
+	inputhandler.SetExample(inputhandler.Example{
+		Answers: map[string]string{
+			"Part1": "10605",
+			"Part2": "2713310158",
+		},
+	})
+}
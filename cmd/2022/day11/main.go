@@ -2,10 +2,12 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"context"
 	"fmt"
 	"math/big"
-	"os"
 	"sort"
+	"strings"
 )
 
 /*
@@ -132,6 +134,23 @@ func CreateTestMonkeyGroup(useRelief bool) []Monkey[int] {
 	return temp
 }
 
+// CreatePuzzleMonkeyGroup creates the monkeys of the puzzle's input, the same as
+// CreateTestMonkeyGroupModulo() but with plain worry levels.
+func CreatePuzzleMonkeyGroup(useRelief bool) []Monkey[int] {
+	var temp = make([]Monkey[int], 8)
+
+	temp[0] = *NewMonkey([]int{99, 67, 92, 61, 83, 64, 98}, Multiplication, 17, useRelief, 3, 4, 2)
+	temp[1] = *NewMonkey([]int{78, 74, 88, 89, 50}, Multiplication, 11, useRelief, 5, 3, 5)
+	temp[2] = *NewMonkey([]int{98, 91}, Addition, 4, useRelief, 2, 6, 4)
+	temp[3] = *NewMonkey([]int{59, 72, 94, 91, 79, 88, 94, 51}, Power, 0, useRelief, 13, 0, 5)
+	temp[4] = *NewMonkey([]int{95, 72, 78}, Addition, 7, useRelief, 11, 7, 6)
+	temp[5] = *NewMonkey([]int{76}, Addition, 8, useRelief, 17, 0, 2)
+	temp[6] = *NewMonkey([]int{69, 60, 53, 89, 71, 88}, Addition, 5, useRelief, 19, 7, 1)
+	temp[7] = *NewMonkey([]int{72, 54, 63, 80}, Addition, 3, useRelief, 7, 1, 3)
+
+	return temp
+}
+
 func CreateTestMonkeyGroupBig(useRelief bool) []Monkey[*big.Int] {
 	var temp = make([]Monkey[*big.Int], 4)
 
@@ -184,32 +203,39 @@ func NewMonkeyBig(itemWorryLevels []int, opFN Operation[*big.Int], opVal2 int, u
 //-----------------------------------------------------------------------------
 
 func CreateTestMonkeyGroupModulo(useRelief bool) []Monkey[ModuloInt] {
-	/* test
-	var temp = make([]Monkey[ModuloInt], 4)
 
-	temp[0] = *NewMonkeyModulo([]int{79, 98}, MultiplicationModulo, 19, useRelief, 23, 2, 3)
-	temp[1] = *NewMonkeyModulo([]int{54, 65, 75, 74}, AdditionModulo, 6, useRelief, 19, 2, 0)
-	temp[2] = *NewMonkeyModulo([]int{79, 60, 97}, PowerModulo, 0, useRelief, 13, 1, 3)
-	temp[3] = *NewMonkeyModulo([]int{74}, AdditionModulo, 3, useRelief, 17, 0, 1)
-	*/
+	// least common multiple of the test divisions (3,5,2,13,11,17,19,7)
+	mod := 9699690
 
 	var temp = make([]Monkey[ModuloInt], 8)
-	temp[0] = *NewMonkeyModulo([]int{99, 67, 92, 61, 83, 64, 98}, MultiplicationModulo, 17, useRelief, 3, 4, 2)
-	temp[1] = *NewMonkeyModulo([]int{78, 74, 88, 89, 50}, MultiplicationModulo, 11, useRelief, 5, 3, 5)
-	temp[2] = *NewMonkeyModulo([]int{98, 91}, AdditionModulo, 4, useRelief, 2, 6, 4)
-	temp[3] = *NewMonkeyModulo([]int{59, 72, 94, 91, 79, 88, 94, 51}, PowerModulo, 0, useRelief, 13, 0, 5)
-	temp[4] = *NewMonkeyModulo([]int{95, 72, 78}, AdditionModulo, 7, useRelief, 11, 7, 6)
-	temp[5] = *NewMonkeyModulo([]int{76}, AdditionModulo, 8, useRelief, 17, 0, 2)
-	temp[6] = *NewMonkeyModulo([]int{69, 60, 53, 89, 71, 88}, AdditionModulo, 5, useRelief, 19, 7, 1)
-	temp[7] = *NewMonkeyModulo([]int{72, 54, 63, 80}, AdditionModulo, 3, useRelief, 7, 1, 3)
+	temp[0] = *NewMonkeyModulo([]int{99, 67, 92, 61, 83, 64, 98}, MultiplicationModulo, 17, useRelief, 3, 4, 2, mod)
+	temp[1] = *NewMonkeyModulo([]int{78, 74, 88, 89, 50}, MultiplicationModulo, 11, useRelief, 5, 3, 5, mod)
+	temp[2] = *NewMonkeyModulo([]int{98, 91}, AdditionModulo, 4, useRelief, 2, 6, 4, mod)
+	temp[3] = *NewMonkeyModulo([]int{59, 72, 94, 91, 79, 88, 94, 51}, PowerModulo, 0, useRelief, 13, 0, 5, mod)
+	temp[4] = *NewMonkeyModulo([]int{95, 72, 78}, AdditionModulo, 7, useRelief, 11, 7, 6, mod)
+	temp[5] = *NewMonkeyModulo([]int{76}, AdditionModulo, 8, useRelief, 17, 0, 2, mod)
+	temp[6] = *NewMonkeyModulo([]int{69, 60, 53, 89, 71, 88}, AdditionModulo, 5, useRelief, 19, 7, 1, mod)
+	temp[7] = *NewMonkeyModulo([]int{72, 54, 63, 80}, AdditionModulo, 3, useRelief, 7, 1, 3, mod)
 
 	return temp
 }
 
-func NewMonkeyModulo(itemWorryLevels []int, opFN Operation[ModuloInt], opVal2 int, useRelief bool, testVal2 int, testResTrue int, testResFalse int) *Monkey[ModuloInt] {
+// CreateExampleMonkeyGroupModulo creates the monkeys of the puzzle's worked example.
+func CreateExampleMonkeyGroupModulo(useRelief bool) []Monkey[ModuloInt] {
+
 	// least common multiple of (23,19,13,17)
-	//mod := 96577//test
-	mod := 9699690
+	mod := 96577
+
+	var temp = make([]Monkey[ModuloInt], 4)
+	temp[0] = *NewMonkeyModulo([]int{79, 98}, MultiplicationModulo, 19, useRelief, 23, 2, 3, mod)
+	temp[1] = *NewMonkeyModulo([]int{54, 65, 75, 74}, AdditionModulo, 6, useRelief, 19, 2, 0, mod)
+	temp[2] = *NewMonkeyModulo([]int{79, 60, 97}, PowerModulo, 0, useRelief, 13, 1, 3, mod)
+	temp[3] = *NewMonkeyModulo([]int{74}, AdditionModulo, 3, useRelief, 17, 0, 1, mod)
+
+	return temp
+}
+
+func NewMonkeyModulo(itemWorryLevels []int, opFN Operation[ModuloInt], opVal2 int, useRelief bool, testVal2 int, testResTrue int, testResFalse int, mod int) *Monkey[ModuloInt] {
 	var temp = Monkey[ModuloInt]{
 		OpFN:            opFN,
 		OpVal2:          *NewModuloInt(opVal2, mod),
@@ -237,8 +263,11 @@ func NewModuloInt(val, m int) *ModuloInt {
 	return &ModuloInt{val: val, mod: m}
 }
 
+func (m ModuloInt) String() string {
+	return fmt.Sprint(m.val)
+}
+
 func AdditionModulo(val1, val2 ModuloInt) ModuloInt {
-	//fmt.Printf("    increases by %d", val2)
 	res := val1.val + val2.val
 	if (res > val1.val) == (val2.val > 0) {
 		res = res % val1.mod
@@ -248,7 +277,6 @@ func AdditionModulo(val1, val2 ModuloInt) ModuloInt {
 }
 
 func MultiplicationModulo(val1, val2 ModuloInt) ModuloInt {
-	//fmt.Printf("    multiplied by %d", val2)
 	res := val1.val * val2.val
 	if (res < 0) == ((val1.val < 0) != (val2.val < 0)) {
 		if res/val2.val == val1.val {
@@ -260,12 +288,10 @@ func MultiplicationModulo(val1, val2 ModuloInt) ModuloInt {
 }
 
 func PowerModulo(val1, val2 ModuloInt) ModuloInt {
-	//fmt.Printf("    multiplied by %d", val1)
 	return MultiplicationModulo(val1, val1)
 }
 
 func IsDivisableModulo(val1, val2 ModuloInt) bool {
-	//fmt.Printf("    divided by 3 to %d\n", val1.Int64())
 	return val1.val%val2.val == 0
 }
 
@@ -299,42 +325,98 @@ func LCM(a, b int, integers ...int) int {
 
 func main() {
 
-	/*
-		outputhandler.Initialize()
-		defer outputhandler.Reset()
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
 
+	/*
 		lines := inputhandler.ReadInput()
 	*/
-	/*
-		// Part 1
-		monkeyBusinessLevelPart1, err := startStuffSlingingSimianShenanigans(CreateTestMonkeyGroup(true), 20)
-		if err != nil {
-			fmt.Printf("Error doing part 1 stuff-slinging simian shenanigans: %v", err)
-			os.Exit(int(inputhandler.ErrorCodeProcessing))
+	results := outputhandler.NewResults(2022, 11, "Monkey business level")
+	ctx := inputhandler.GetContext()
+
+	if results.SolveReference(nil) {
+		results.Print()
+		return
+	}
+
+	// Part 1
+	if inputhandler.IsPartRequested(1) {
+		monkeys := CreatePuzzleMonkeyGroup(true)
+		if inputhandler.IsExampleRequested() {
+			monkeys = CreateTestMonkeyGroup(true)
 		}
-	*/
+		monkeyBusinessLevelPart1, throws, rounds, err := startStuffSlingingSimianShenanigans(ctx, monkeys, 20)
+		if err == nil || inputhandler.IsStopError(err) {
+			printThrows(throws, rounds)
+		}
+		if inputhandler.IsStopError(err) {
+			results.AddStopped("Part1", fmt.Sprintf("level %d after %d of %d rounds", monkeyBusinessLevelPart1, rounds, 20))
+		} else if err != nil {
+			outputhandler.PrintError("Error doing part 1 stuff-slinging simian shenanigans", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		} else {
+			results.Add("Part1", monkeyBusinessLevelPart1)
+		}
+	}
+
 	// Part 2
-	//monkeyBusinessLevelPart2, err := startStuffSlingingSimianShenanigans(CreateTestMonkeyGroupBig(false), 10000)
-	monkeyBusinessLevelPart2, err := startStuffSlingingSimianShenanigans(CreateTestMonkeyGroupModulo(false), 10000)
-	if err != nil {
-		fmt.Printf("Error doing part 2 stuff-slinging simian shenanigans: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(2) {
+		//monkeyBusinessLevelPart2, rounds, err := startStuffSlingingSimianShenanigans(ctx, CreateTestMonkeyGroupBig(false), 10000)
+		monkeys := CreateTestMonkeyGroupModulo(false)
+		if inputhandler.IsExampleRequested() {
+			monkeys = CreateExampleMonkeyGroupModulo(false)
+		}
+		maxRounds := inputhandler.GetParam("rounds", 10000) // lower for the reference solver
+		monkeyBusinessLevelPart2, throws, rounds, err := startStuffSlingingSimianShenanigans(ctx, monkeys, maxRounds)
+		if err == nil || inputhandler.IsStopError(err) {
+			printThrows(throws, rounds)
+		}
+		if inputhandler.IsStopError(err) {
+			results.AddStopped("Part2", fmt.Sprintf("level %d after %d of %d rounds", monkeyBusinessLevelPart2, rounds, maxRounds))
+		} else if err != nil {
+			outputhandler.PrintError("Error doing part 2 stuff-slinging simian shenanigans", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		} else {
+			results.Add("Part2", monkeyBusinessLevelPart2)
+		}
 	}
 
-	fmt.Printf("Result - Part1: %d, Part2: %d", monkeyBusinessLevelPart2, monkeyBusinessLevelPart2)
+	results.Print()
+}
+
+// printThrows prints how many items each monkey threw in the rounds
+func printThrows(throws []int, rounds int) {
+
+	throwsTable := outputhandler.NewTable(fmt.Sprintf("After round %d", rounds),
+		outputhandler.TableColumn{Header: "Monkey", Align: outputhandler.AlignRight},
+		outputhandler.TableColumn{Header: "Throws", Align: outputhandler.AlignRight, Color: outputhandler.BrightCyan},
+	)
+	for monkeyIdx, monkeyThrows := range throws {
+		throwsTable.AddRow(monkeyIdx, monkeyThrows)
+	}
+	throwsTable.Print()
 }
 
-func startStuffSlingingSimianShenanigans[T any](monkeys []Monkey[T], maxRounds int) (int, error) {
+var traceRounds = outputhandler.NewTracer("day11", "rounds")
+var traceItems = outputhandler.NewTracer("day11", "items")
+
+// startStuffSlingingSimianShenanigans returns the monkey business level, the throws by monkey and the number
+// of rounds done. When the context is done, it stops with the level so far and the context's error.
+func startStuffSlingingSimianShenanigans[T any](ctx context.Context, monkeys []Monkey[T], maxRounds int) (int, []int, int, error) {
 
 	if len(monkeys) < 2 {
-		return 0, fmt.Errorf("not enough monkeys for shenanigans '%d'", len(monkeys))
+		return 0, nil, 0, fmt.Errorf("not enough monkeys for shenanigans '%d'", len(monkeys))
 	}
 
+	roundsDone := 0
 	for round := 1; round <= maxRounds; round++ {
-		fmt.Printf("round %d:\n", round)
+		if ctx.Err() != nil {
+			break
+		}
+		traceRounds.Debugf("round %d", round)
 
 		for monkeyIdx := range monkeys {
-			//fmt.Printf("monkey %d:\n", monkeyIdx)
+			traceItems.Verbosef("monkey %d:", monkeyIdx)
 
 			for {
 				if err := monkeys[monkeyIdx].InspectFirst(); err != nil {
@@ -342,45 +424,46 @@ func startStuffSlingingSimianShenanigans[T any](monkeys []Monkey[T], maxRounds i
 				}
 
 				if item, toMonkeyIdx, err := monkeys[monkeyIdx].ThrowFirst(); err != nil {
-					return 0, fmt.Errorf("monkey '%d' tried to throw with empty hands on round '%d'", monkeyIdx, round) // shouldn't be possible
+					return 0, nil, roundsDone, fmt.Errorf("monkey '%d' tried to throw with empty hands on round '%d'", monkeyIdx, round) // shouldn't be possible
 				} else {
 					monkeys[toMonkeyIdx].Catch(item)
 				}
 			}
 		}
-		/*
-			if round == 1000 {
-				fmt.Printf("after round %d:\n", round)
-				for monkeyIdx, monkey := range monkeys {
-					fmt.Printf("monkey %d: %d\n", monkeyIdx, monkey.Throws)
-				}
-				fmt.Println()
+		if traceRounds.IsEnabled(outputhandler.TraceDebug) {
+			throws := make([]string, len(monkeys))
+			for monkeyIdx, monkey := range monkeys {
+				throws[monkeyIdx] = fmt.Sprint(monkey.Throws)
 			}
-		*/
-		/*
-			fmt.Printf("after round %d:\n", round)
+			traceRounds.Debugf("after round %d, throws by monkey: %s", round, strings.Join(throws, ", "))
+		}
+		if traceItems.IsEnabled(outputhandler.TraceDebug) {
 			for monkeyIdx, monkey := range monkeys {
-				fmt.Printf("monkey %d: ", monkeyIdx)
-				for _, item := range monkey.Items {
-					fmt.Printf("%v, ", item.WorryLevel)
+				levels := make([]string, len(monkey.Items))
+				for itemIdx, item := range monkey.Items {
+					levels[itemIdx] = fmt.Sprint(item.WorryLevel)
 				}
-				fmt.Println()
+				traceItems.Debugf("after round %d, monkey %d holds: %s", round, monkeyIdx, strings.Join(levels, ", "))
 			}
-			fmt.Println()
-		*/
+		}
+		roundsDone = round
 	}
 
-	fmt.Printf("after round %d:\n", maxRounds)
+	throws := make([]int, len(monkeys))
 	for monkeyIdx, monkey := range monkeys {
-		fmt.Printf("monkey %d: %d\n", monkeyIdx, monkey.Throws)
+		throws[monkeyIdx] = monkey.Throws
 	}
-	fmt.Println()
 
 	sort.Slice(monkeys, func(i, j int) bool {
 		return monkeys[i].Throws < monkeys[j].Throws
 	})
 
-	return monkeys[len(monkeys)-1].Throws * monkeys[len(monkeys)-2].Throws, nil
+	level := monkeys[len(monkeys)-1].Throws * monkeys[len(monkeys)-2].Throws
+	if roundsDone < maxRounds {
+		return level, throws, roundsDone, ctx.Err()
+	}
+
+	return level, throws, roundsDone, nil
 }
 
 //-----------------------------------------------------------------------------
@@ -389,7 +472,6 @@ func startStuffSlingingSimianShenanigans[T any](monkeys []Monkey[T], maxRounds i
 type Operation[T any] func(T, T) T
 
 func Addition(val1, val2 int) int {
-	//fmt.Printf("    increases by %d", val2)
 	res := val1 + val2
 	if (res > val1) == (val2 > 0) {
 		return res
@@ -398,7 +480,6 @@ func Addition(val1, val2 int) int {
 }
 
 func Multiplication(val1, val2 int) int {
-	//fmt.Printf("    multiplied by %d", val2)
 	res := val1 * val2
 	if (res < 0) == ((val1 < 0) != (val2 < 0)) {
 		if res/val2 == val1 {
@@ -409,24 +490,20 @@ func Multiplication(val1, val2 int) int {
 }
 
 func Power(val1, val2 int) int {
-	//fmt.Printf("    multiplied by %d", val1)
 	return val1 * val1
 }
 
 func AdditionBig(val1, val2 *big.Int) *big.Int {
-	//fmt.Printf("    increases by %d", val2.Int64())
 	var res big.Int
 	return res.Add(val1, val2)
 }
 
 func MultiplicationBig(val1, val2 *big.Int) *big.Int {
-	//fmt.Printf("    multiplied by %d", val2.Int64())
 	var res big.Int
 	return res.Mul(val1, val2)
 }
 
 func PowerBig(val1, val2 *big.Int) *big.Int {
-	//fmt.Printf("    multiplied by %d", val1.Int64())
 	var res big.Int
 	return res.Mul(val1, val1)
 }
@@ -435,11 +512,9 @@ func PowerBig(val1, val2 *big.Int) *big.Int {
 type ModTest[T any] func(T, T) bool
 
 func IsDivisable(val1, val2 int) bool {
-	//fmt.Printf("    divided by 3 to %d\n", val1)
 	return val1%val2 == 0
 }
 func IsDivisableBig(val1, val2 *big.Int) bool {
-	//fmt.Printf("    divided by 3 to %d\n", val1.Int64())
 	var res big.Int
 	return res.Mod(val1, val2).Cmp(big.NewInt(0)) == 0
 }
@@ -483,10 +558,10 @@ func (m *Monkey[T]) InspectFirst() error {
 	}
 
 	oldWorryLevel := m.Items[0].WorryLevel
-	//fmt.Printf("  inspects an item with a worry level: %d\n", oldWorryLevel)
-
 	newWorryLevel := m.OpFN(oldWorryLevel, m.OpVal2)
-	//fmt.Printf(" to %d\n", newWorryLevel)
+	if traceItems.IsEnabled(outputhandler.TraceVerbose) {
+		traceItems.Verbosef("  inspects an item with a worry level of %v, goes up to %v", oldWorryLevel, newWorryLevel)
+	}
 	if m.UseRelief {
 		m.Items[0].WorryLevel = m.ReliefFN(newWorryLevel)
 	} else {
@@ -506,16 +581,17 @@ func (m *Monkey[T]) ThrowFirst() (Item[T], int, error) {
 	m.Items = m.Items[1:]
 
 	var throwTo int
-	if m.TestFN(itemToThrow.WorryLevel, m.TestVal2) {
-		//fmt.Printf("    is divisible by %d\n", m.TestDivision)
+	isDivisible := m.TestFN(itemToThrow.WorryLevel, m.TestVal2)
+	if isDivisible {
 		throwTo = m.TestResultTrue
 	} else {
-		//fmt.Printf("    not divisible by %d\n", m.TestDivision)
 		throwTo = m.TestResultFalse
 	}
 
 	m.Throws++
-	//fmt.Printf("    throw %d to monkey %d\n", itemToThrow.WorryLevel, throwTo)
+	if traceItems.IsEnabled(outputhandler.TraceVerbose) {
+		traceItems.Verbosef("    divisible by %v: %t, throw %v to monkey %d", m.TestVal2, isDivisible, itemToThrow.WorryLevel, throwTo)
+	}
 	return itemToThrow, throwTo, nil
 }
 
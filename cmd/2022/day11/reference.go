@@ -0,0 +1,235 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference reads the monkeys from their notes as the puzzle gives them and plays
+// the rounds with the worry levels as they are, without the modulo arithmetic and
+// without the Monkey type of the solution. The levels of part 2 grow fast, so it only
+// finishes with fewer rounds, like -param rounds=100.
+func solveReference(ctx context.Context, _ []string, part int) (interface{}, error) {
+
+	notes := referencePuzzleNotes
+	if inputhandler.IsExampleRequested() {
+		notes = referenceExampleNotes
+	}
+	monkeys, err := parseReferenceNotes(notes)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := 20
+	if part == 2 {
+		rounds = inputhandler.GetParam("rounds", 10000)
+	}
+
+	three := big.NewInt(3)
+	remainder := new(big.Int)
+	inspections := make([]int, len(monkeys))
+	for round := 0; round < rounds; round++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for monkeyIdx := range monkeys {
+			monkey := &monkeys[monkeyIdx]
+			for _, level := range monkey.items {
+				inspections[monkeyIdx]++
+
+				switch {
+				case monkey.operation == "*" && monkey.operand == nil:
+					level.Mul(level, level)
+				case monkey.operation == "*":
+					level.Mul(level, monkey.operand)
+				default:
+					level.Add(level, monkey.operand)
+				}
+				if part == 1 {
+					level.Div(level, three)
+				}
+
+				target := monkey.ifFalse
+				if remainder.Mod(level, monkey.divisor).Sign() == 0 {
+					target = monkey.ifTrue
+				}
+				monkeys[target].items = append(monkeys[target].items, level)
+			}
+			monkey.items = nil
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(inspections)))
+
+	return inspections[0] * inspections[1], nil
+}
+
+// referenceMonkey is a monkey of the notes, a nil operand means "old"
+type referenceMonkey struct {
+	items           []*big.Int
+	operation       string
+	operand         *big.Int
+	divisor         *big.Int
+	ifTrue, ifFalse int
+}
+
+// parseReferenceNotes reads the notes like the ones of the puzzle
+func parseReferenceNotes(notes string) ([]referenceMonkey, error) {
+
+	monkeys := make([]referenceMonkey, 0)
+	for _, block := range strings.Split(strings.TrimSpace(notes), "\n\n") {
+		lines := strings.Split(block, "\n")
+		if len(lines) != 6 {
+			return nil, fmt.Errorf("invalid notes of monkey %d", len(monkeys))
+		}
+		for idx := range lines {
+			_, lines[idx], _ = strings.Cut(lines[idx], ":")
+			lines[idx] = strings.TrimSpace(lines[idx])
+		}
+
+		var monkey referenceMonkey
+		for _, item := range strings.Split(lines[1], ", ") {
+			level, ok := new(big.Int).SetString(item, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid item '%s' of monkey %d", item, len(monkeys))
+			}
+			monkey.items = append(monkey.items, level)
+		}
+
+		operation := strings.Fields(lines[2]) // new = old * 19
+		if len(operation) != 5 || (operation[3] != "*" && operation[3] != "+") {
+			return nil, fmt.Errorf("invalid operation '%s' of monkey %d", lines[2], len(monkeys))
+		}
+		monkey.operation = operation[3]
+		if operation[4] != "old" {
+			operand, ok := new(big.Int).SetString(operation[4], 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid operation '%s' of monkey %d", lines[2], len(monkeys))
+			}
+			monkey.operand = operand
+		}
+
+		values := make([]int, 3) // the divisor and the two targets are the last words
+		for idx := range values {
+			fields := strings.Fields(lines[3+idx])
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("invalid notes of monkey %d", len(monkeys))
+			}
+			value, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid notes of monkey %d: %w", len(monkeys), err)
+			}
+			values[idx] = value
+		}
+		monkey.divisor = big.NewInt(int64(values[0]))
+		monkey.ifTrue, monkey.ifFalse = values[1], values[2]
+
+		monkeys = append(monkeys, monkey)
+	}
+
+	for monkeyIdx, monkey := range monkeys {
+		if monkey.ifTrue < 0 || monkey.ifTrue >= len(monkeys) || monkey.ifFalse < 0 || monkey.ifFalse >= len(monkeys) {
+			return nil, fmt.Errorf("monkey %d throws to an unknown monkey", monkeyIdx)
+		}
+	}
+
+	return monkeys, nil
+}
+
+const referenceExampleNotes = `
+Monkey 0:
+  Starting items: 79, 98
+  Operation: new = old * 19
+  Test: divisible by 23
+    If true: throw to monkey 2
+    If false: throw to monkey 3
+
+Monkey 1:
+  Starting items: 54, 65, 75, 74
+  Operation: new = old + 6
+  Test: divisible by 19
+    If true: throw to monkey 2
+    If false: throw to monkey 0
+
+Monkey 2:
+  Starting items: 79, 60, 97
+  Operation: new = old * old
+  Test: divisible by 13
+    If true: throw to monkey 1
+    If false: throw to monkey 3
+
+Monkey 3:
+  Starting items: 74
+  Operation: new = old + 3
+  Test: divisible by 17
+    If true: throw to monkey 0
+    If false: throw to monkey 1
+`
+
+const referencePuzzleNotes = `
+Monkey 0:
+  Starting items: 99, 67, 92, 61, 83, 64, 98
+  Operation: new = old * 17
+  Test: divisible by 3
+    If true: throw to monkey 4
+    If false: throw to monkey 2
+
+Monkey 1:
+  Starting items: 78, 74, 88, 89, 50
+  Operation: new = old * 11
+  Test: divisible by 5
+    If true: throw to monkey 3
+    If false: throw to monkey 5
+
+Monkey 2:
+  Starting items: 98, 91
+  Operation: new = old + 4
+  Test: divisible by 2
+    If true: throw to monkey 6
+    If false: throw to monkey 4
+
+Monkey 3:
+  Starting items: 59, 72, 94, 91, 79, 88, 94, 51
+  Operation: new = old * old
+  Test: divisible by 13
+    If true: throw to monkey 0
+    If false: throw to monkey 5
+
+Monkey 4:
+  Starting items: 95, 72, 78
+  Operation: new = old + 7
+  Test: divisible by 11
+    If true: throw to monkey 7
+    If false: throw to monkey 6
+
+Monkey 5:
+  Starting items: 76
+  Operation: new = old + 8
+  Test: divisible by 17
+    If true: throw to monkey 0
+    If false: throw to monkey 2
+
+Monkey 6:
+  Starting items: 69, 60, 53, 89, 71, 88
+  Operation: new = old + 5
+  Test: divisible by 19
+    If true: throw to monkey 7
+    If false: throw to monkey 1
+
+Monkey 7:
+  Starting items: 72, 54, 63, 80
+  Operation: new = old + 3
+  Test: divisible by 7
+    If true: throw to monkey 1
+    If false: throw to monkey 3
+`
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseInput checks that any height map is parsed or rejected, without a panic.
+func FuzzParseInput(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("SE\nab")
+	f.Add("SbE\nS\nEE")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, _, _, err := parseInput(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(80, generateHeightMap)
+}
+
+// generateHeightMap creates a size wide map that rises from S in the top left corner to
+// E in the bottom right one. The top row and the right column keep the slope, so there
+// is always a path, the rest is dented randomly.
+func generateHeightMap(rng *rand.Rand, size int) inputhandler.Generated {
+
+	width := size
+	if width < 20 {
+		width = 20
+	}
+	height := width/3 + 8
+
+	lines := make([]string, height)
+	for y := range lines {
+		row := make([]byte, width)
+		for x := range row {
+			level := 25 * (x + y) / (width + height - 2)
+			if y > 0 && x < width-1 && rng.Intn(2) == 0 {
+				level -= rng.Intn(4)
+				if level < 0 {
+					level = 0
+				}
+			}
+			row[x] = byte('a' + level)
+		}
+		lines[y] = string(row)
+	}
+
+	lines[0] = "S" + lines[0][1:]
+	lines[height-1] = lines[height-1][:width-1] + "E"
+
+	return inputhandler.Generated{Lines: lines}
+}
@@ -4,8 +4,10 @@ import (
 	"AoC22/internal/inputhandler"
 	"AoC22/internal/outputhandler"
 	"fmt"
+	"image/color"
 	"math"
 	"sort"
+	"unicode/utf8"
 )
 
 var pathColor string
@@ -88,6 +90,47 @@ func visualizePath(steps []Location, playfield PlayField) {
 
 }
 
+// visualizeHeightMap draws the height map with the path as an image, lower parts
+// are darker. Falls back to visualizePath() if the terminal can't show images.
+func visualizeHeightMap(steps []Location, playfield PlayField) {
+
+	img := outputhandler.NewGridImage(playfield.Width, playfield.Height, 4, func(x, y int) color.Color {
+		if _, isOnPath := isSliceContains(Location{x: x, y: y}, steps); isOnPath {
+			return outputhandler.ImageColor(outputhandler.BrightGreen)
+		}
+		level := uint8((playfield.getHeightAt(x, y) - int('a')) * 9)
+		return color.NRGBA{R: level / 2, G: level / 2, B: 30 + level, A: 255}
+	})
+
+	if !outputhandler.PrintImage(img) {
+		visualizePath(steps, playfield)
+	}
+}
+
+// exportPathSVG writes the height map with the path on top into an SVG file.
+// Lower parts are darker, so the climb is visible in the browser. The steps are
+// goal first and without the start, as pathFind() returns them.
+func exportPathSVG(start Location, steps []Location, playfield PlayField, path string) error {
+
+	svg := outputhandler.NewSVGGrid(playfield.Width, playfield.Height)
+	svg.CellColor = func(x, y int) string {
+		level := playfield.getHeightAt(x, y) - int('a')
+		return fmt.Sprintf("hsl(%d, 40%%, %d%%)", 120-level*3, 10+level*2)
+	}
+	svg.CellText = func(x, y int) string {
+		return string(rune(playfield.getHeightAt(x, y)))
+	}
+
+	points := make([]outputhandler.SVGPoint, 0, len(steps)+1)
+	points = append(points, outputhandler.SVGPoint{X: start.x, Y: start.y})
+	for stepIdx := len(steps) - 1; stepIdx >= 0; stepIdx-- {
+		points = append(points, outputhandler.SVGPoint{X: steps[stepIdx].x, Y: steps[stepIdx].y})
+	}
+	svg.AddPolyline(points, outputhandler.CSSColor(outputhandler.BrightGreen), fmt.Sprintf("%d steps", len(steps)))
+
+	return outputhandler.WriteSVGFile(path, svg)
+}
+
 func main() {
 
 	outputhandler.Initialize()
@@ -96,63 +139,118 @@ func main() {
 	mapColor = outputhandler.GetReset()
 
 	lines := inputhandler.ReadInput()
-	playField, start, goal := parseInput(lines)
+	results := outputhandler.NewResults(2022, 12, "Steps")
+	playField, start, goal, err := parseInput(lines)
+	if err != nil {
+		outputhandler.PrintError("Error: while parsing the height map", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
 
 	// Part 1
-	stepsPart1, found := pathFind(start, goal, *playField)
-	if !found {
-		panic("no solution")
+	if inputhandler.IsPartRequested(1) {
+		stepsPart1, found := pathFind(start, goal, *playField)
+		if !found {
+			panic("no solution")
+		}
+		//ReverseSlice(stepsPart1)
+		//visualizePath(stepsPart1, *playField)
+		if path := outputhandler.GetSVGPath(); len(path) > 0 {
+			if err := exportPathSVG(start, stepsPart1, *playField, path); err != nil {
+				outputhandler.PrintError("Warning", err)
+			}
+		}
+		if outputhandler.IsGraphicsRequested() {
+			visualizeHeightMap(stepsPart1, *playField)
+		}
+		results.Add("Part1", len(stepsPart1))
 	}
-	//ReverseSlice(stepsPart1)
-	//visualizePath(stepsPart1, *playField)
 
 	// Part 2
-	stepsListPart2 := make([]int, 0)
-	for vIdx, heightMapLine := range playField.heightMap {
-		for hIdx, height := range heightMapLine {
+	if inputhandler.IsPartRequested(2) {
+		ctx := inputhandler.GetContext()
+		var startsChecked int
+		stepsListPart2 := make([]int, 0)
+	checkStarts:
+		for vIdx, heightMapLine := range playField.heightMap {
+			for hIdx, height := range heightMapLine {
+
+				if height != int('a') {
+					continue
+				}
 
-			if height != int('a') {
-				continue
-			}
+				if ctx.Err() != nil {
+					break checkStarts
+				}
+				startsChecked++
 
-			steps, found := pathFind(Location{x: hIdx, y: vIdx}, Location{x: goal.x, y: goal.y}, *playField)
-			if !found {
-				//panic("no solution")
-				continue
-			}
+				steps, found := pathFind(Location{x: hIdx, y: vIdx}, Location{x: goal.x, y: goal.y}, *playField)
+				if !found {
+					//panic("no solution")
+					continue
+				}
 
-			//ReverseSlice(steps)
-			//visualizePath(steps, *playField)
+				//ReverseSlice(steps)
+				//visualizePath(steps, *playField)
 
-			stepsListPart2 = append(stepsListPart2, len(steps))
+				stepsListPart2 = append(stepsListPart2, len(steps))
+			}
+		}
+		sort.Ints(stepsListPart2)
+
+		switch {
+		case ctx.Err() != nil && len(stepsListPart2) > 0:
+			results.AddStopped("Part2", fmt.Sprintf("%d steps at best from the %d starting points checked", stepsListPart2[0], startsChecked))
+		case ctx.Err() != nil:
+			results.AddStopped("Part2", fmt.Sprintf("no path from the %d starting points checked", startsChecked))
+		case len(stepsListPart2) == 0:
+			panic("no solution")
+		default:
+			results.Add("Part2", stepsListPart2[0])
 		}
 	}
-	sort.Ints(stepsListPart2)
 
-	fmt.Printf("Result - Part1: %d, Part2: %d", len(stepsPart1), stepsListPart2[0])
+	results.Print()
 }
 
-func parseInput(lines []string) (*PlayField, Location, Location) {
+func parseInput(lines []string) (*PlayField, Location, Location, error) {
 	heightMap := make([][]int, 0, len(lines))
 	var startPos Location
 	var goalPos Location
+	var startFound, goalFound bool
 	for vIdx, line := range lines {
+		if utf8.RuneCountInString(line) != utf8.RuneCountInString(lines[0]) {
+			return nil, startPos, goalPos, inputhandler.NewParseError(vIdx, line, -1, "invalid row length '%d', expected '%d'", utf8.RuneCountInString(line), utf8.RuneCountInString(lines[0]))
+		}
+
 		row := make([]int, len(line))
-		for hIdx, val := range line {
-			switch val {
-			case 'S':
+		for hIdx, val := range line { // hIdx is the byte index, any other than 'a'-'z' is invalid anyway
+			switch {
+			case val == 'S' && !startFound:
 				startPos = Location{x: hIdx, y: vIdx}
+				startFound = true
 				val = 'a'
-			case 'E':
+			case val == 'E' && !goalFound:
 				goalPos = Location{x: hIdx, y: vIdx}
+				goalFound = true
 				val = 'z'
+			case val < 'a' || val > 'z':
+				return nil, startPos, goalPos, inputhandler.NewParseError(vIdx, line, hIdx, "invalid height '%c'", val)
 			}
 			row[hIdx] = int(val)
 		}
 		heightMap = append(heightMap, row)
 	}
 
-	return NewPlayField(heightMap), startPos, goalPos
+	if !startFound || !goalFound {
+		return nil, startPos, goalPos, inputhandler.NewParseError(-1, "", -1, "the start 'S' or the goal 'E' is missing")
+	}
+
+	return NewPlayField(heightMap), startPos, goalPos, nil
 }
 
 type Position struct {
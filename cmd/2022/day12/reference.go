@@ -0,0 +1,73 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"fmt"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference walks the map backwards from the goal breadth first, the first
+// time a square is reached is its distance. No A*, no path finding per start.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	playField, start, goal, err := parseInput(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	distances := make([][]int, playField.Height)
+	for vIdx := range distances {
+		distances[vIdx] = make([]int, playField.Width)
+		for hIdx := range distances[vIdx] {
+			distances[vIdx][hIdx] = -1
+		}
+	}
+
+	distances[goal.y][goal.x] = 0
+	queue := []Position{{X: goal.x, Y: goal.y}}
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		curr := queue[0]
+		queue = queue[1:]
+
+		for _, next := range []Position{{curr.X + 1, curr.Y}, {curr.X - 1, curr.Y}, {curr.X, curr.Y + 1}, {curr.X, curr.Y - 1}} {
+			if next.X < 0 || next.Y < 0 || next.X >= playField.Width || next.Y >= playField.Height {
+				continue
+			}
+			// backwards, so the step is from next to curr
+			if distances[next.Y][next.X] >= 0 || playField.getHeightAt(curr.X, curr.Y) > playField.getHeightAt(next.X, next.Y)+1 {
+				continue
+			}
+			distances[next.Y][next.X] = distances[curr.Y][curr.X] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	if part == 1 {
+		if distances[start.y][start.x] < 0 {
+			return nil, fmt.Errorf("no path from the start")
+		}
+		return distances[start.y][start.x], nil
+	}
+
+	fewest := -1
+	for vIdx := range distances {
+		for hIdx, distance := range distances[vIdx] {
+			if playField.getHeightAt(hIdx, vIdx) == int('a') && distance >= 0 && (fewest < 0 || distance < fewest) {
+				fewest = distance
+			}
+		}
+	}
+	if fewest < 0 {
+		return nil, fmt.Errorf("no path from any 'a'")
+	}
+
+	return fewest, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseSignal checks that any list of packets is parsed or rejected, without a panic.
+// The parsed packets are compared too, they can be any mix of lists and integers.
+func FuzzParseSignal(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("[1,[2]]\n[[],3]")
+	f.Add("[1,]\n[,2]\n[1]]\n[[1]\n5]\n[1],[2]")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		signal, err := parseSignal(lines)
+		parsetest.CheckError(t, err)
+		if err != nil {
+			return
+		}
+
+		_, _ = processSignal(signal)
+		findDecoderKey(signal)
+	})
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetGenerator(150, generatePacketPairs)
+}
+
+// generatedPacket is an integer or a list, like the packets of the solution
+type generatedPacket struct {
+	value int
+	list  []generatedPacket // nil for integers
+}
+
+// generatePacketPairs creates size pairs of packets. The order of every pair is decided,
+// and no packet is equal to the divider packets.
+func generatePacketPairs(rng *rand.Rand, size int) inputhandler.Generated {
+
+	dividers := []generatedPacket{
+		{list: []generatedPacket{{list: []generatedPacket{{value: 2}}}}},
+		{list: []generatedPacket{{list: []generatedPacket{{value: 6}}}}},
+	}
+	isValid := func(packet generatedPacket) bool {
+		return comparePackets(packet, dividers[0]) != 0 && comparePackets(packet, dividers[1]) != 0
+	}
+
+	lines := make([]string, 0, size*3)
+	for len(lines) < size*3 {
+		left := generatedPacket{list: randomPacketList(rng, 0)}
+		right := generatedPacket{list: randomPacketList(rng, 0)}
+		if comparePackets(left, right) == 0 || !isValid(left) || !isValid(right) {
+			continue
+		}
+
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, formatPacket(left), formatPacket(right))
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
+
+func randomPacketList(rng *rand.Rand, depth int) []generatedPacket {
+
+	list := make([]generatedPacket, rng.Intn(6))
+	for idx := range list {
+		if depth < 4 && rng.Intn(3) == 0 {
+			list[idx].list = randomPacketList(rng, depth+1)
+		} else {
+			list[idx].value = rng.Intn(11)
+		}
+	}
+
+	return list
+}
+
+func formatPacket(packet generatedPacket) string {
+
+	if packet.list == nil {
+		return strconv.Itoa(packet.value)
+	}
+
+	items := make([]string, len(packet.list))
+	for idx, item := range packet.list {
+		items[idx] = formatPacket(item)
+	}
+
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+// comparePackets returns <0 if left comes first, >0 if right does, 0 if it's undecided
+func comparePackets(left, right generatedPacket) int {
+
+	switch {
+	case left.list == nil && right.list == nil:
+		return left.value - right.value
+	case left.list == nil:
+		return comparePackets(generatedPacket{list: []generatedPacket{left}}, right)
+	case right.list == nil:
+		return comparePackets(left, generatedPacket{list: []generatedPacket{right}})
+	}
+
+	for idx := 0; idx < len(left.list) && idx < len(right.list); idx++ {
+		if order := comparePackets(left.list[idx], right.list[idx]); order != 0 {
+			return order
+		}
+	}
+
+	return len(left.list) - len(right.list)
+}
@@ -2,8 +2,8 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
 	"fmt"
-	"os"
 	"reflect"
 	"sort"
 	"strconv"
@@ -11,45 +11,68 @@ import (
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 13, "Result")
 
 	signal, err := parseSignal(lines)
 	if err != nil {
-		fmt.Printf("Error parsing signal: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		outputhandler.PrintError("Error parsing signal", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
 	}
 
 	// Part 1
-	//fmt.Println("-----------------------------------------")
+	if inputhandler.IsPartRequested(1) {
+		inOrderCount, err := processSignal(signal)
+		if err != nil {
+			outputhandler.PrintError("Error processing signal Part 1", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
 
-	inOrderCount, err := processSignal(signal)
-	if err != nil {
-		fmt.Printf("Error processing signal Part 1: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		results.Add("Part1", inOrderCount)
 	}
 
 	// Part 2
-	//fmt.Println("-----------------------------------------")
+	if inputhandler.IsPartRequested(2) {
+		decoderKey := findDecoderKey(signal)
 
-	decoderKey := findDecoderKey(signal)
+		results.Add("Part2", decoderKey)
+	}
 
-	fmt.Printf("Result: Part1: %d, Part2: %d", inOrderCount, decoderKey)
+	results.Print()
 }
 
+var traceParsing = outputhandler.NewTracer("day13", "parsing")
+var traceCompare = outputhandler.NewTracer("day13", "compare")
+
 func parseSignal(lines []string) ([]interface{}, error) {
 
 	signal := make([]interface{}, 0)
-	for _, line := range lines {
+	for lineIdx, line := range lines {
 
 		if len(line) == 0 {
 			continue
 		}
 
-		temp, _, err := parseSlice(0, line, make([]interface{}, 0))
+		if line[0] != '[' {
+			return nil, inputhandler.NewParseError(lineIdx, line, 0, "a packet must start with a '['")
+		}
+
+		temp, endIdx, err := parseSlice(lineIdx, 1, line, make([]interface{}, 0))
 		if err != nil {
-			return nil, fmt.Errorf("error while parsing line '%s': %w", line, err)
+			return nil, err
+		}
+		if endIdx != len(line)-1 {
+			return nil, inputhandler.NewParseError(lineIdx, line, endIdx+1, "unexpected data after the packet")
 		}
-		//fmt.Println()
+		//visualizePacket(temp)
 
 		signal = append(signal, temp)
 	}
@@ -57,41 +80,38 @@ func parseSignal(lines []string) ([]interface{}, error) {
 	return signal, nil
 }
 
-// assume correct format - no spaces, starts with a '['
-func parseSlice(startIdx int, line string, parent []interface{}) ([]interface{}, int, error) {
+// parses the list starting after its '[' at startIdx, returns it with the index of its ']'
+func parseSlice(lineIdx int, startIdx int, line string, parent []interface{}) ([]interface{}, int, error) {
 
 	var tempVal []byte
 	for charIdx := startIdx; charIdx < len(line); charIdx++ {
 
 		switch line[charIdx] {
 		case '[':
-			//fmt.Println("slice start")
+			traceParsing.Verbosef("slice start at %d", charIdx)
 
-			temp, tempIdx, err := parseSlice(charIdx+1, line, make([]interface{}, 0))
+			temp, tempIdx, err := parseSlice(lineIdx, charIdx+1, line, make([]interface{}, 0))
 			if err != nil {
 				return nil, 0, err
 			}
 			parent = append(parent, temp)
 			charIdx = tempIdx
 
-			if charIdx == len(line)-1 {
-				return parent, charIdx, nil
-			}
-
 		case ']':
-			//fmt.Print("slice end")
-
+			if len(tempVal) == 0 && line[charIdx-1] == ',' {
+				return nil, 0, inputhandler.NewParseError(lineIdx, line, charIdx, "missing value while encountering a ']'")
+			}
 			if len(tempVal) > 0 {
 
 				intVal, err := strconv.Atoi(string(tempVal))
 				if err != nil {
-					return nil, 0, fmt.Errorf("couldn't convert '%s' to int", tempVal)
+					return nil, 0, inputhandler.NewParseError(lineIdx, line, charIdx-len(tempVal), "couldn't convert '%s' to int", tempVal)
 				}
 				parent = append(parent, intVal)
 
-				//fmt.Printf(" - append data %d", intVal)
+				traceParsing.Verbosef("append data %d", intVal)
 			}
-			//fmt.Println()
+			traceParsing.Verbosef("slice end at %d", charIdx)
 
 			return parent, charIdx, nil
 
@@ -100,19 +120,19 @@ func parseSlice(startIdx int, line string, parent []interface{}) ([]interface{},
 
 				intVal, err := strconv.Atoi(string(tempVal))
 				if err != nil {
-					return nil, 0, fmt.Errorf("couldn't convert '%s' to int", tempVal)
+					return nil, 0, inputhandler.NewParseError(lineIdx, line, charIdx-len(tempVal), "couldn't convert '%s' to int", tempVal)
 				}
 
 				parent = append(parent, intVal)
-				//fmt.Printf("append data %d\n", intVal)
+				traceParsing.Verbosef("append data %d", intVal)
 
 				tempVal = []byte{}
 				continue
 			}
 
 			// could be a slice
-			if line[charIdx-1] != ']' {
-				return nil, 0, fmt.Errorf("missing value while encountering a ','")
+			if charIdx == startIdx || line[charIdx-1] != ']' {
+				return nil, 0, inputhandler.NewParseError(lineIdx, line, charIdx, "missing value while encountering a ','")
 			}
 
 		default:
@@ -120,7 +140,54 @@ func parseSlice(startIdx int, line string, parent []interface{}) ([]interface{},
 		}
 	}
 
-	return nil, 0, fmt.Errorf("unexpected end of data")
+	return nil, 0, inputhandler.NewParseError(lineIdx, line, len(line), "unexpected end of data")
+}
+
+//-----------------------------------------------------------------------------
+
+// packetNode makes the packets printable by outputhandler.PrintTree
+type packetNode struct {
+	data interface{}
+}
+
+func (pn packetNode) Label() string {
+	if reflect.ValueOf(pn.data).Kind() == reflect.Slice {
+		return "[]"
+	}
+	return fmt.Sprint(pn.data)
+}
+
+func (pn packetNode) Children() []packetNode {
+
+	dataValue := reflect.ValueOf(pn.data)
+	if dataValue.Kind() != reflect.Slice {
+		return nil
+	}
+
+	children := make([]packetNode, dataValue.Len())
+	for idx := range children {
+		children[idx] = packetNode{data: dataValue.Index(idx).Interface()}
+	}
+	return children
+}
+
+func visualizePacket(packet interface{}) {
+	outputhandler.PrintTree(packetNode{data: packet}, outputhandler.TreeOptions[packetNode]{
+		TreeColor:    outputhandler.Gray,
+		CollapseOver: 10,
+		Color: func(node packetNode) outputhandler.TerminalColor {
+			if reflect.ValueOf(node.data).Kind() == reflect.Slice {
+				return outputhandler.BrightCyan
+			}
+			return outputhandler.BrightGreen
+		},
+		Annotate: func(node packetNode) (string, outputhandler.TerminalColor) {
+			if dataValue := reflect.ValueOf(node.data); dataValue.Kind() == reflect.Slice {
+				return fmt.Sprintf("(%d)", dataValue.Len()), outputhandler.DarkGray
+			}
+			return "", outputhandler.DarkGray
+		},
+	})
 }
 
 //-----------------------------------------------------------------------------
@@ -137,11 +204,9 @@ func processSignal(signal []interface{}) (int, error) {
 		order := processPair(signal[packetIdx], signal[packetIdx+1])
 
 		if order != WrongOrder {
-			//fmt.Printf("was good: %d\n", packetIdx/2+1)
+			traceCompare.Debugf("pair %d is in the right order", packetIdx/2+1)
 			sumInOrderIdx += packetIdx/2 + 1
 		}
-
-		//fmt.Println()
 	}
 
 	return sumInOrderIdx, nil
@@ -155,13 +220,13 @@ func processPair(left, right interface{}) Order {
 	if leftKind != rightKind { // one is a slice
 
 		if leftKind != reflect.Slice {
-			//fmt.Println("slicify left")
+			traceCompare.Verbosef("slicify left")
 			left = slicifyData(left)
 			leftKind = reflect.ValueOf(left).Kind()
 		}
 
 		if rightKind != reflect.Slice {
-			//fmt.Println("slicify right")
+			traceCompare.Verbosef("slicify right")
 			right = slicifyData(right)
 			//rightKind = reflect.ValueOf(right).Kind()
 		}
@@ -177,7 +242,7 @@ func processPair(left, right interface{}) Order {
 // left should be longer
 // continue on equal if values didn't decide
 func compareSlices(left, right interface{}) Order {
-	//fmt.Println("compare slice")
+	traceCompare.Verbosef("compare slice")
 
 	leftValue := reflect.ValueOf(left)
 	rightValue := reflect.ValueOf(right)
@@ -216,7 +281,7 @@ func compareData(left, right interface{}) Order {
 
 	leftValue := reflect.ValueOf(left)
 	rightValue := reflect.ValueOf(right)
-	//fmt.Printf("comparing: %d, %d\n", leftValue.Int(), rightValue.Int())
+	traceCompare.Verbosef("comparing: %d, %d", leftValue.Int(), rightValue.Int())
 
 	if leftValue.Int() == rightValue.Int() {
 		return Undecided
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"encoding/json"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference reads the packets as JSON and compares them without reflection. The
+// decoder key is from counting the packets before the dividers, nothing is sorted.
+func solveReference(_ context.Context, lines []string, part int) (interface{}, error) {
+
+	packets := make([]interface{}, 0, len(lines))
+	for lineIdx, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		var packet interface{}
+		if err := json.Unmarshal([]byte(line), &packet); err != nil {
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid packet: %w", err)
+		}
+		packets = append(packets, packet)
+	}
+
+	if part == 1 {
+		var sum int
+		for pairIdx := 0; pairIdx+1 < len(packets); pairIdx += 2 {
+			if compareJSONPackets(packets[pairIdx], packets[pairIdx+1]) <= 0 {
+				sum += pairIdx/2 + 1
+			}
+		}
+		return sum, nil
+	}
+
+	// the dividers are at 1 and 2 without the other packets, [[2]] comes first
+	divider1, divider2 := []interface{}{[]interface{}{2.0}}, []interface{}{[]interface{}{6.0}}
+	position1, position2 := 1, 2
+	for _, packet := range packets {
+		if compareJSONPackets(packet, divider1) < 0 {
+			position1++
+		}
+		if compareJSONPackets(packet, divider2) < 0 {
+			position2++
+		}
+	}
+
+	return position1 * position2, nil
+}
+
+// compareJSONPackets returns <0 if left comes first, >0 if right does, 0 if it's
+// undecided. The numbers are float64, as the JSON decoder gives them.
+func compareJSONPackets(left, right interface{}) int {
+
+	leftNumber, leftIsNumber := left.(float64)
+	rightNumber, rightIsNumber := right.(float64)
+	switch {
+	case leftIsNumber && rightIsNumber:
+		switch {
+		case leftNumber < rightNumber:
+			return -1
+		case leftNumber > rightNumber:
+			return 1
+		}
+		return 0
+	case leftIsNumber:
+		return compareJSONPackets([]interface{}{left}, right)
+	case rightIsNumber:
+		return compareJSONPackets(left, []interface{}{right})
+	}
+
+	leftList, rightList := left.([]interface{}), right.([]interface{})
+	for idx := 0; idx < len(leftList) && idx < len(rightList); idx++ {
+		if order := compareJSONPackets(leftList[idx], rightList[idx]); order != 0 {
+			return order
+		}
+	}
+
+	return len(leftList) - len(rightList)
+}
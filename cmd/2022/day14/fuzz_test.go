@@ -0,0 +1,27 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseScan checks that any scan of the rocks is parsed or rejected, without a panic.
+// The parsed rocks are put in the cave too, to check that they fit in it.
+func FuzzParseScan(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("498,4 -> 498,6 -> 496,6\n1,2")
+	f.Add("0,0 -> 1000,0\n5,5 -> 6,6\n-1,2 -> 3,4")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		rockPaths, dimensions, err := parseScan(lines)
+		parsetest.CheckError(t, err)
+		if err != nil {
+			return
+		}
+
+		NewCaveSlice(dimensions, rockPaths)
+	})
+}
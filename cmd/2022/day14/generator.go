@@ -0,0 +1,42 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetGenerator(150, generateRockPaths)
+}
+
+// generateRockPaths creates size paths of rock around the sand's source. The paths
+// turn at right angles and stay below the source.
+func generateRockPaths(rng *rand.Rand, size int) inputhandler.Generated {
+
+	lines := make([]string, size)
+	for idx := range lines {
+		x, y := 440+rng.Intn(120), 10+rng.Intn(160)
+		points := []string{fmt.Sprintf("%d,%d", x, y)}
+		horizontal := rng.Intn(2) == 0
+		for segment := 1 + rng.Intn(5); segment > 0; segment-- {
+			length := 1 + rng.Intn(10)
+			if rng.Intn(2) == 0 {
+				length = -length
+			}
+			if horizontal {
+				x += length
+			} else if y+length > 1 {
+				y += length
+			} else {
+				y -= length
+			}
+			horizontal = !horizontal
+			points = append(points, fmt.Sprintf("%d,%d", x, y))
+		}
+		lines[idx] = strings.Join(points, " -> ")
+	}
+
+	return inputhandler.Generated{Lines: lines}
+}
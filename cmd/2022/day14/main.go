@@ -2,41 +2,63 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"context"
 	"fmt"
-	"math"
-	"os"
 	"strconv"
 	"strings"
 )
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 14, "Rested sand")
 
 	rockPaths, dimensions, err := parseScan(lines)
 	if err != nil {
-		fmt.Printf("Error while parsing data: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		outputhandler.PrintError("Error while parsing data", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
 	}
 
-	caveSlice := NewCaveSlice(dimensions, rockPaths)
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
 
-	err = caveSlice.Simulate(500, true)
-	if err != nil {
-		fmt.Printf("Error in part 1 simulation: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	caveSlice := NewCaveSlice(dimensions, rockPaths)
+	ctx := inputhandler.GetContext()
+
+	if inputhandler.IsPartRequested(1) {
+		err = caveSlice.Simulate(ctx, 500, true)
+		if inputhandler.IsStopError(err) {
+			results.AddStopped("Part1", fmt.Sprintf("%d units of sand rested so far", caveSlice.countRested()))
+		} else if err != nil {
+			outputhandler.PrintError("Error in part 1 simulation", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		} else {
+			restedSandCountPart1 := caveSlice.countRested()
+			results.Add("Part1", restedSandCountPart1)
+		}
 	}
-	restedSandCountPart1 := caveSlice.countRested()
 
-	caveSlice.ClearSand()
-	err = caveSlice.Simulate(500, false)
-	if err != nil {
-		fmt.Printf("Error in part 1 simulation: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(2) {
+		caveSlice.ClearSand()
+		err = caveSlice.Simulate(ctx, 500, false)
+		if inputhandler.IsStopError(err) {
+			results.AddStopped("Part2", fmt.Sprintf("%d units of sand rested so far", caveSlice.countRested()))
+		} else if err != nil {
+			outputhandler.PrintError("Error in part 2 simulation", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		} else {
+			restedSandCountPart2 := caveSlice.countRested()
+			results.Add("Part2", restedSandCountPart2)
+		}
 	}
-	restedSandCountPart2 := caveSlice.countRested()
 
-	fmt.Printf("Result - Part1: %d, Part2: %d", restedSandCountPart1, restedSandCountPart2)
+	results.Print()
 }
 
 //-----------------------------------------------------------------------------
@@ -50,38 +72,51 @@ type Dimensions struct {
 	MinY, MaxY int
 }
 
+// the cave is kept in memory as a grid, the coordinates are limited to keep it small
+const maxScanCoord = 1000
+
 func parseScan(scanData []string) ([][]Position, Dimensions, error) {
 
 	rockPaths := make([][]Position, 0)
 
-	var minX, minY = math.MaxInt, math.MaxInt
-	var maxX, maxY int
+	// the drop in point of the sand is always in the cave, even if no rock is around it
+	var minX, minY = 500, 0
+	var maxX, maxY = 500, 0
 	for rockPathIdx, rockPathLine := range scanData {
 
 		rockCoords := strings.Split(rockPathLine, " -> ")
 		if len(rockCoords) < 2 {
-			return nil, Dimensions{}, fmt.Errorf("too few coordinates in line number '%d'", rockPathIdx+1)
+			return nil, Dimensions{}, inputhandler.NewParseError(rockPathIdx, rockPathLine, -1, "too few coordinates")
 		}
 
 		rockPath := make([]Position, 0)
 
+		coordColumn := 0
 		for _, coordStr := range rockCoords {
 
 			coord := strings.Split(coordStr, ",")
-			if len(coord) < 2 {
-				return nil, Dimensions{}, fmt.Errorf("invalid coordinates '%s' in line number '%d'", coordStr, rockPathIdx+1)
+			if len(coord) != 2 {
+				return nil, Dimensions{}, inputhandler.NewParseError(rockPathIdx, rockPathLine, coordColumn, "invalid coordinates '%s'", coordStr)
 			}
 
 			coordX, err := strconv.Atoi(coord[0])
-			if err != nil {
-				return nil, Dimensions{}, fmt.Errorf("failed to convert X coordinate to int in '%s' at line number '%d'", coordStr, rockPathIdx+1)
+			if err != nil || coordX < 0 || coordX > maxScanCoord {
+				return nil, Dimensions{}, inputhandler.NewParseError(rockPathIdx, rockPathLine, coordColumn, "failed to convert X coordinate to int in '%s'", coordStr)
 			}
 			coordY, err := strconv.Atoi(coord[1])
-			if err != nil {
-				return nil, Dimensions{}, fmt.Errorf("failed to convert Y coordinate to int in '%s' at line number '%d'", coordStr, rockPathIdx+1)
+			if err != nil || coordY < 0 || coordY > maxScanCoord {
+				return nil, Dimensions{}, inputhandler.NewParseError(rockPathIdx, rockPathLine, coordColumn+len(coord[0])+1, "failed to convert Y coordinate to int in '%s'", coordStr)
 			}
 
 			pathCoord := Position{coordX, coordY}
+			if len(rockPath) > 0 {
+				prevCoord := rockPath[len(rockPath)-1]
+				if prevCoord.X != pathCoord.X && prevCoord.Y != pathCoord.Y {
+					return nil, Dimensions{}, inputhandler.NewParseError(rockPathIdx, rockPathLine, coordColumn, "the rock path can't go diagonally to '%s'", coordStr)
+				}
+			}
+			coordColumn += len(coordStr) + len(" -> ")
+
 			rockPath = append(rockPath, pathCoord)
 
 			if pathCoord.X < minX {
@@ -160,7 +195,9 @@ func NewCaveSlice(dimensions Dimensions, rockPaths [][]Position) *CaveSlice {
 	return &caveSlice
 }
 
-func (cs *CaveSlice) Simulate(dropInPos int, finite bool) error {
+// Simulate drops sand until it falls to the abyss (finite) or blocks the source.
+// When the context is done, it stops with the context's error, the sand stays where it is.
+func (cs *CaveSlice) Simulate(ctx context.Context, dropInPos int, finite bool) error {
 
 	dropInPos += cs.PointOffset.X
 
@@ -169,6 +206,10 @@ func (cs *CaveSlice) Simulate(dropInPos int, finite bool) error {
 	for {
 		iterCount++
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		// seed
 		cs.Field[0][dropInPos] = SandMoving
 
@@ -374,11 +415,67 @@ func GetGaussSum(val int) int {
 
 //-----------------------------------------------------------------------------
 
+var caveViewport = outputhandler.NewViewport()
+
+// visualize prints the part of the cave that fits the terminal, following the
+// falling sand, or the drop in point if there is none.
 func visualize(caveSlice *CaveSlice) {
 
-	for vIdx := 0; vIdx <= caveSlice.Dimensions.MaxY; vIdx++ {
-		fmt.Println(string(caveSlice.Field[vIdx]))
+	if outputhandler.IsInteractive() {
+		exploreCave(caveSlice)
+		return
 	}
 
+	lines := make([]string, caveSlice.Dimensions.MaxY+1)
+	for vIdx := range lines {
+		lines[vIdx] = string(caveSlice.Field[vIdx])
+	}
+
+	// rock and sand are not told apart in the dense views
+	density := outputhandler.GetRequestedDensity()
+	if density != outputhandler.DensityNormal {
+		lines = outputhandler.RenderGrid(outputhandler.GridFromStrings(lines, "#o~"), density)
+	}
+
+	focus := Position{X: 500 + caveSlice.PointOffset.X, Y: 0}
+	for vIdx := range caveSlice.Field {
+		for hIdx, cell := range caveSlice.Field[vIdx] {
+			if cell == SandMoving {
+				focus = Position{X: hIdx, Y: vIdx}
+			}
+		}
+	}
+	cellsPerCharX, cellsPerCharY := density.GetCellsPerChar()
+	caveViewport.Follow(focus.X/cellsPerCharX, focus.Y/cellsPerCharY)
+
+	caveViewport.Print(lines)
 	fmt.Println()
 }
+
+var cellTypeNames = map[CellType]string{
+	Air:        "air",
+	Rock:       "rock",
+	SandStatic: "sand",
+	SandMoving: "falling sand",
+}
+
+// exploreCave lets the user look around the cave, starting from the drop in point.
+// The cursor shows the cave coordinates, not the slice indexes.
+func exploreCave(caveSlice *CaveSlice) {
+
+	grid := &outputhandler.ExplorerGrid{
+		Width:  len(caveSlice.Field[0]),
+		Height: len(caveSlice.Field),
+		Cell: func(x, y, _ int) rune {
+			return rune(caveSlice.Field[y][x])
+		},
+		Value: func(x, y, _ int) string {
+			return fmt.Sprintf("%s at %d,%d", cellTypeNames[caveSlice.Field[y][x]], x-caveSlice.PointOffset.X, y)
+		},
+		Empty: rune(Air),
+	}
+
+	if err := outputhandler.ExploreGrid(grid, 500+caveSlice.PointOffset.X, 0, 0); err != nil {
+		outputhandler.PrintError("Error while exploring the cave", err)
+	}
+}
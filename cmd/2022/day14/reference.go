@@ -0,0 +1,68 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference drops the sand one unit at a time into a set of the blocked
+// positions, the floor of part 2 is checked as it is, no extrapolated sides.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	rockPaths, dimensions, err := parseScan(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make(map[Position]bool)
+	for _, rockPath := range rockPaths {
+		for pathIdx := 0; pathIdx < len(rockPath)-1; pathIdx++ {
+			startX, startY, endX, endY := GetMinMax(rockPath[pathIdx], rockPath[pathIdx+1])
+			for x := startX; x <= endX; x++ {
+				for y := startY; y <= endY; y++ {
+					blocked[Position{x, y}] = true
+				}
+			}
+		}
+	}
+
+	floorY := dimensions.MaxY + 2
+	source := Position{500, 0}
+	rested := 0
+	for !blocked[source] {
+		if err := ctx.Err(); err != nil {
+			return rested, err
+		}
+
+		sand := source
+		for {
+			if part == 1 && sand.Y > dimensions.MaxY {
+				return rested, nil // falls to the abyss
+			}
+			if part == 2 && sand.Y+1 == floorY {
+				break
+			}
+
+			moved := false
+			for _, next := range []Position{{sand.X, sand.Y + 1}, {sand.X - 1, sand.Y + 1}, {sand.X + 1, sand.Y + 1}} {
+				if !blocked[next] {
+					sand = next
+					moved = true
+					break
+				}
+			}
+			if !moved {
+				break
+			}
+		}
+
+		blocked[sand] = true
+		rested++
+	}
+
+	return rested, nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	_ "embed"
+)
+
+//go:embed example.txt
+var exampleInput string
+
+func init() {
+	inputhandler.SetExample(inputhandler.Example{
+		Input: exampleInput,
+		Answers: map[string]string{
+			"Part1": "26",
+			"Part2": "56000011",
+		},
+		Params: map[string]int{
+			"row":  10,
+			"area": 20,
+		},
+	})
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseSensorData checks that any list of sensors is parsed or rejected, without a panic.
+func FuzzParseSensorData(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("Sensor at x=2, y=18: closest beacon is at x=-2, y=15\nSensor at x=1")
+	f.Add("y=1 x=2 y=3 x=4\nx=99999999999999999999, y=0, x=0, y=0")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, _, err := parseSensorData(lines)
+		parsetest.CheckError(t, err)
+	})
+}
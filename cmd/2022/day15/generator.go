@@ -0,0 +1,66 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(4000000, generateSensors)
+}
+
+// generateSensors creates the sensors for a size x size search area, with exactly one
+// position left uncovered for the distress beacon. A grid of sensors covers the area,
+// the ones near the hidden position are shrunk to leave it out, and four sensors at its
+// diagonals cover the rest around it. Rows and the area are returned as the parameters.
+func generateSensors(rng *rand.Rand, size int) inputhandler.Generated {
+
+	if size < 16 {
+		size = 16
+	}
+
+	// even, so the diamonds of the neighboring sensors overlap
+	spacing := (size / 5) &^ 1
+	hidden := Position{X: rng.Intn(size + 1), Y: rng.Intn(size + 1)}
+
+	lines := make([]string, 0, 50)
+	addSensor := func(sensor Position, radius int) {
+		// the beacon is somewhere on the edge of the covered diamond
+		dx := rng.Intn(radius + 1)
+		dy := radius - dx
+		if rng.Intn(2) == 0 {
+			dx = -dx
+		}
+		if rng.Intn(2) == 0 {
+			dy = -dy
+		}
+		lines = append(lines, fmt.Sprintf("Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d",
+			sensor.X, sensor.Y, sensor.X+dx, sensor.Y+dy))
+	}
+
+	diagonals := make(map[Position]bool)
+	for _, dir := range []Position{{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: -1, Y: -1}} {
+		sensor := Position{X: hidden.X + dir.X*2*spacing, Y: hidden.Y + dir.Y*2*spacing}
+		diagonals[sensor] = true
+		addSensor(sensor, 4*spacing-1)
+	}
+
+	for y := 0; y <= size+spacing/2; y += spacing {
+		for x := 0; x <= size+spacing/2; x += spacing {
+			sensor := Position{X: x, Y: y}
+			radius := spacing
+			if distance := CalcDistance(sensor, hidden); distance <= spacing {
+				radius = distance - 1
+			}
+			if radius < 1 || diagonals[sensor] {
+				continue
+			}
+			addSensor(sensor, radius)
+		}
+	}
+
+	rng.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+
+	return inputhandler.Generated{Lines: lines, Params: map[string]int{"area": size, "row": size / 2}}
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"context"
+	"fmt"
+	"image/color"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+func main() {
+
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
+	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 15, "Result")
+
+	sensors, dimensions, err := parseSensorData(lines)
+	if err != nil {
+		outputhandler.PrintError("Error while parsing data", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	ctx := inputhandler.GetContext()
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	if inputhandler.IsPartRequested(1) {
+		resultPart1, err := countNoBeaconPosOnRow(ctx, inputhandler.GetParam("row", 2000000), sensors, dimensions)
+		if err != nil {
+			results.AddStopped("Part1", fmt.Sprintf("%d positions counted so far", resultPart1))
+		} else {
+			results.Add("Part1", resultPart1)
+		}
+	}
+
+	if inputhandler.IsPartRequested(2) {
+		areaSize := inputhandler.GetParam("area", 4000000)
+		checkArea := Dimensions{
+			MinX: 0,
+			MaxX: areaSize,
+			MinY: 0,
+			MaxY: areaSize,
+		}
+		resultPart2, rowsChecked, err := getFreqOfFirstPossibleBeaconPos(ctx, sensors, checkArea)
+		if inputhandler.IsStopError(err) {
+			results.AddStopped("Part2", fmt.Sprintf("%d of %d rows checked", rowsChecked, checkArea.MaxY-checkArea.MinY+1))
+		} else if err != nil {
+			outputhandler.PrintError("Error while processing part 2", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		} else {
+			results.Add("Part2", resultPart2)
+
+			if outputhandler.IsGraphicsRequested() {
+				visualizeCoverage(sensors, checkArea, Position{X: resultPart2 / 4000000, Y: resultPart2 % 4000000})
+			}
+		}
+	}
+
+	results.Print()
+}
+
+//-----------------------------------------------------------------------------
+
+type Dimensions struct {
+	MinX, MaxX int
+	MinY, MaxY int
+}
+
+func NewDimensions() *Dimensions {
+	return &Dimensions{
+		MinX: math.MaxInt,
+		MaxX: 0,
+		MinY: math.MaxInt,
+		MaxY: 0,
+	}
+}
+
+func (d *Dimensions) Update(x, y int) {
+
+	if x < d.MinX {
+		d.MinX = x
+	}
+	if x > d.MaxX {
+		d.MaxX = x
+	}
+	if y < d.MinY {
+		d.MinY = y
+	}
+	if y > d.MaxY {
+		d.MaxY = y
+	}
+}
+
+type Position struct {
+	X, Y int
+}
+
+func (p *Position) DistanceFrom(val Position) int {
+	return CalcDistance(*p, val)
+}
+
+type Beacon struct {
+	Position
+}
+
+func NewBeacon(x, y int) *Beacon {
+	return &Beacon{
+		Position: Position{X: x, Y: y},
+	}
+}
+
+type Sensor struct {
+	Position
+	closestBeacon  Beacon
+	BeaconDistance int
+}
+
+func NewSensor(x, y int) *Sensor {
+	return &Sensor{
+		Position: Position{X: x, Y: y},
+	}
+}
+
+func (s *Sensor) SetClosestBeacon(beacon Beacon) {
+	s.closestBeacon = beacon
+	s.BeaconDistance = s.DistanceFrom(beacon.Position)
+}
+
+func parseSensorData(lines []string) ([]Sensor, Dimensions, error) {
+
+	coordsPattern, err := regexp.Compile(`x=(-?\d+)|y=(-?\d+)`)
+	if err != nil {
+		// shouldn't be possible
+		return nil, Dimensions{}, fmt.Errorf("couldn't compile coordinate parser regex")
+	}
+
+	dimensions := *NewDimensions()
+
+	sensors := make([]Sensor, 0, len(lines))
+	for lineIdx, line := range lines {
+
+		coords := coordsPattern.FindAllStringSubmatch(line, -1)
+		if coords == nil || len(coords) < 4 {
+			return nil, Dimensions{}, inputhandler.NewParseError(lineIdx, line, -1, "too few coordinates found '%d'", len(coords))
+		}
+		coordColumns := coordsPattern.FindAllStringIndex(line, -1)
+
+		senX, err := strconv.Atoi(coords[0][1])
+		if err != nil {
+			return nil, Dimensions{}, inputhandler.NewParseError(lineIdx, line, coordColumns[0][0], "couldn't parse sensor's X coordinate from '%s'", coords[0][0])
+		}
+
+		senY, err := strconv.Atoi(coords[1][2])
+		if err != nil {
+			return nil, Dimensions{}, inputhandler.NewParseError(lineIdx, line, coordColumns[1][0], "couldn't parse sensor's Y coordinate from '%s'", coords[1][0])
+		}
+
+		beacX, err := strconv.Atoi(coords[2][1])
+		if err != nil {
+			return nil, Dimensions{}, inputhandler.NewParseError(lineIdx, line, coordColumns[2][0], "couldn't parse beacon's X coordinate from '%s'", coords[2][0])
+		}
+
+		beacY, err := strconv.Atoi(coords[3][2])
+		if err != nil {
+			return nil, Dimensions{}, inputhandler.NewParseError(lineIdx, line, coordColumns[3][0], "couldn't parse beacon's Y coordinate from '%s'", coords[3][0])
+		}
+
+		dimensions.Update(senX, senY)
+		dimensions.Update(beacX, beacY)
+
+		sensor := NewSensor(senX, senY)
+		beacon := NewBeacon(beacX, beacY)
+		sensor.SetClosestBeacon(*beacon)
+
+		sensors = append(sensors, *sensor)
+	}
+
+	return sensors, dimensions, nil
+}
+
+//-----------------------------------------------------------------------------
+
+var traceRow = outputhandler.NewTracer("day15", "row")
+
+// Part 1
+// countNoBeaconPosOnRow returns the positions in the row where the beacon can't be.
+// When the context is done, it returns the count so far with the context's error.
+func countNoBeaconPosOnRow(ctx context.Context, row int, sensors []Sensor, dimensions Dimensions) (int, error) {
+	traceRow.Debugf("minX: %d, maxX: %d", dimensions.MinX, dimensions.MaxX)
+
+	var count int
+
+	canBeBeacon := func(position Position) bool {
+
+		// exclude existing beacons, duh, even if an earlier sensor covers them
+		for _, sensor := range sensors {
+			if position == sensor.closestBeacon.Position {
+				return true
+			}
+		}
+
+		for _, sensor := range sensors {
+			if sensor.DistanceFrom(position) <= sensor.BeaconDistance {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// middle out algo - if u got the ref :)
+	//var line string
+	middleIdx := (dimensions.MaxX - dimensions.MinX) / 2
+
+	leftIdx := middleIdx
+	for {
+
+		if canBeBeacon(Position{X: leftIdx, Y: row}) {
+			//line = "." + line
+
+			if leftIdx < dimensions.MinX {
+				break
+			}
+		} else {
+			//line = "#" + line
+
+			count++
+		}
+
+		leftIdx--
+
+		// checking on every position would be too slow
+		if leftIdx%contextCheckInterval == 0 && ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+	}
+
+	rightIdx := middleIdx + 1
+	for {
+
+		if canBeBeacon(Position{X: rightIdx, Y: row}) {
+			//line = line + "."
+
+			if rightIdx > dimensions.MaxX {
+				break
+			}
+		} else {
+			//line = line + "#"
+
+			count++
+		}
+
+		rightIdx++
+
+		if rightIdx%contextCheckInterval == 0 && ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+	}
+	traceRow.Debugf("counted from: %d to: %d", leftIdx, rightIdx)
+
+	return count, nil
+}
+
+const contextCheckInterval = 4096
+
+// Part 2
+// getFreqOfFirstPossibleBeaconPos returns the tuning frequency and the number of rows checked.
+// When the context is done, it stops with the context's error.
+func getFreqOfFirstPossibleBeaconPos(ctx context.Context, sensors []Sensor, dimensions Dimensions) (int, int, error) {
+
+	progress := outputhandler.NewProgressBar("rows checked", int64(dimensions.MaxY-dimensions.MinY+1))
+	defer progress.Finish()
+
+	for vIdx := dimensions.MinY; vIdx <= dimensions.MaxY; vIdx++ {
+		progress.Update(int64(vIdx - dimensions.MinY))
+
+		if err := ctx.Err(); err != nil {
+			return 0, vIdx - dimensions.MinY, err
+		}
+
+	nextLine:
+		for hIdx := dimensions.MinX; hIdx <= dimensions.MaxX; hIdx++ {
+
+			for _, sensor := range sensors {
+				if sensor.DistanceFrom(Position{X: hIdx, Y: vIdx}) <= sensor.BeaconDistance {
+
+					// skip this sensor checked area
+					hIdx += sensor.BeaconDistance - GetAbs(sensor.Y-vIdx) + (sensor.X - hIdx)
+
+					continue nextLine
+				}
+			}
+
+			return hIdx*4000000 + vIdx, vIdx - dimensions.MinY + 1, nil
+		}
+	}
+
+	return 0, dimensions.MaxY - dimensions.MinY + 1, fmt.Errorf("no solution found")
+}
+
+//-----------------------------------------------------------------------------
+
+var sensorColors = []outputhandler.TerminalColor{
+	outputhandler.Blue,
+	outputhandler.Green,
+	outputhandler.Cyan,
+	outputhandler.Magenta,
+	outputhandler.Yellow,
+	outputhandler.DarkGray,
+}
+
+// visualizeCoverage draws the areas the sensors see, one color per sensor,
+// with the distress beacon marked in red. The area is way too big for
+// characters, so it's scaled down to an image if the terminal can show one.
+func visualizeCoverage(sensors []Sensor, area Dimensions, beacon Position) {
+
+	const imageSize = 400
+
+	getSensorIdx := func(x, y, width, height int) int {
+		pos := Position{
+			X: area.MinX + x*(area.MaxX-area.MinX)/width,
+			Y: area.MinY + y*(area.MaxY-area.MinY)/height,
+		}
+		for sensorIdx, sensor := range sensors {
+			if sensor.DistanceFrom(pos) <= sensor.BeaconDistance {
+				return sensorIdx
+			}
+		}
+		return -1
+	}
+
+	beaconX := (beacon.X - area.MinX) * imageSize / (area.MaxX - area.MinX)
+	beaconY := (beacon.Y - area.MinY) * imageSize / (area.MaxY - area.MinY)
+
+	img := outputhandler.NewGridImage(imageSize, imageSize, 1, func(x, y int) color.Color {
+		if GetAbs(x-beaconX)+GetAbs(y-beaconY) <= 3 {
+			return outputhandler.ImageColor(outputhandler.BrightRed)
+		}
+		if sensorIdx := getSensorIdx(x, y, imageSize, imageSize); sensorIdx >= 0 {
+			return outputhandler.ImageColor(sensorColors[sensorIdx%len(sensorColors)])
+		}
+		return nil
+	})
+	if outputhandler.PrintImage(img) {
+		return
+	}
+
+	// text fallback, the gap of the beacon is way too small to see
+	density := outputhandler.GetRequestedDensity()
+	cellsX, cellsY := density.GetCellsPerChar()
+	width, height := 64*cellsX, 32*cellsY
+
+	grid := make([][]bool, height)
+	for y := range grid {
+		grid[y] = make([]bool, width)
+		for x := range grid[y] {
+			grid[y][x] = getSensorIdx(x, y, width, height) >= 0
+		}
+	}
+	for _, line := range outputhandler.RenderGrid(grid, density) {
+		fmt.Println(line)
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+func CalcDistance(pos1, pos2 Position) int {
+	return GetAbs(pos1.X-pos2.X) + GetAbs(pos1.Y-pos2.Y)
+}
+
+func GetAbs(val int) int {
+	if val < 0 {
+		return -val
+	}
+	return val
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"fmt"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference checks every position against every sensor, no skipping over the
+// sensors' areas. Part 2 is only feasible for small areas, like -param area=20.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	sensors, dimensions, err := parseSensorData(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	isCovered := func(position Position) bool {
+		for _, sensor := range sensors {
+			if sensor.DistanceFrom(position) <= sensor.BeaconDistance {
+				return true
+			}
+		}
+		return false
+	}
+
+	if part == 1 {
+		row := inputhandler.GetParam("row", 2000000)
+
+		isBeacon := make(map[Position]bool)
+		maxDistance := 0
+		for _, sensor := range sensors {
+			isBeacon[sensor.closestBeacon.Position] = true
+			if sensor.BeaconDistance > maxDistance {
+				maxDistance = sensor.BeaconDistance
+			}
+		}
+
+		// no sensor sees further than this
+		count := 0
+		for x := dimensions.MinX - maxDistance; x <= dimensions.MaxX+maxDistance; x++ {
+			if x%contextCheckInterval == 0 && ctx.Err() != nil {
+				return count, ctx.Err()
+			}
+			position := Position{X: x, Y: row}
+			if isCovered(position) && !isBeacon[position] {
+				count++
+			}
+		}
+
+		return count, nil
+	}
+
+	areaSize := inputhandler.GetParam("area", 4000000)
+	for y := 0; y <= areaSize; y++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for x := 0; x <= areaSize; x++ {
+			if !isCovered(Position{X: x, Y: y}) {
+				return x*4000000 + y, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no solution found")
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseJets checks that any jet pattern is parsed or rejected, without a panic.
+func FuzzParseJets(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("<<>\n>")
+	f.Add("<x>")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := parseJets(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(10091, generateJets)
+}
+
+// generateJets creates a jet pattern of size pushes
+func generateJets(rng *rand.Rand, size int) inputhandler.Generated {
+
+	jets := make([]byte, size)
+	for idx := range jets {
+		jets[idx] = "<>"[rng.Intn(2)]
+	}
+
+	return inputhandler.Generated{Lines: []string{string(jets)}}
+}
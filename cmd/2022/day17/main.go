@@ -2,6 +2,8 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"context"
 	"fmt"
 	"math"
 	"strconv"
@@ -64,15 +66,60 @@ const (
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
-	jets := lines[0]
-	chamber := *NewVerticalChamber()
-	highestPointPart1 := letTheBlocksFall(jets, ShapeList, chamber, 2022, true)
+	results := outputhandler.NewResults(2022, 17, "Tower height")
+	jets, err := parseJets(lines)
+	if err != nil {
+		outputhandler.PrintError("Error while parsing the jets", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	ctx := inputhandler.GetContext()
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	if inputhandler.IsPartRequested(1) {
+		chamber := *NewVerticalChamber()
+		highestPointPart1, rocksFallen, err := letTheBlocksFall(ctx, jets, ShapeList, chamber, 2022, false)
+		if err != nil {
+			results.AddStopped("Part1", fmt.Sprintf("%d of %d rocks fell, the tower is %d high", rocksFallen, 2022, highestPointPart1))
+		} else {
+			results.Add("Part1", highestPointPart1)
+		}
+	}
+
+	if inputhandler.IsPartRequested(2) {
+		chamber := *NewVerticalChamber()
+		highestPointPart2, rocksFallen, err := letTheBlocksFall(ctx, jets, ShapeList, chamber, 1000000000000, true)
+		if err != nil {
+			results.AddStopped("Part2", fmt.Sprintf("%d of %d rocks fell, the tower is %d high", rocksFallen, 1000000000000, highestPointPart2))
+		} else {
+			results.Add("Part2", highestPointPart2)
+		}
+	}
+
+	results.Print()
+}
+
+func parseJets(lines []string) (string, error) {
 
-	chamber = *NewVerticalChamber()
-	highestPointPart2 := letTheBlocksFall(jets, ShapeList, chamber, 1000000000000, true)
+	if len(lines) == 0 || len(lines[0]) == 0 {
+		return "", inputhandler.NewParseError(-1, "", -1, "the jet pattern is missing")
+	}
+
+	for charIdx, jet := range lines[0] {
+		if JetDirection(jet) != Left && JetDirection(jet) != Right {
+			return "", inputhandler.NewParseError(0, lines[0], charIdx, "invalid jet direction '%c'", jet)
+		}
+	}
 
-	fmt.Printf("Result - Part1: %d, Part2: %d", highestPointPart1, highestPointPart2)
+	return lines[0], nil
 }
 
 //-----------------------------------------------------------------------------
@@ -359,6 +406,10 @@ func (vch *VerticalChamber) GetFreeHeightMap() []int {
 	return heightMap
 }
 
+// Hash is the state of the chamber before a rock falls, the same state means the tower repeats.
+// NOTE: the free heights of the columns don't show the overhangs, so a state can repeat when the
+// surface doesn't. Fine for the puzzle's input, but `aoc diff 17 -size 500` finds jet patterns
+// where the repeat is found too early.
 type Hash struct {
 	heightMap    string
 	nextShapeIdx int
@@ -372,7 +423,11 @@ type State struct {
 
 //-----------------------------------------------------------------------------
 
-func letTheBlocksFall(jets string, shapeList []Shape, chamber VerticalChamber, maxShapesToFall int, withPatternDetection bool) int {
+var traceMoves = outputhandler.NewTracer("day17", "moves")
+
+// letTheBlocksFall returns the height of the tower and the number of fallen rocks.
+// When the context is done, it returns what it has so far with the context's error.
+func letTheBlocksFall(ctx context.Context, jets string, shapeList []Shape, chamber VerticalChamber, maxShapesToFall int, withPatternDetection bool) (int, int, error) {
 
 	highestPoint := 0
 
@@ -383,6 +438,9 @@ func letTheBlocksFall(jets string, shapeList []Shape, chamber VerticalChamber, m
 	var shapesFallenOffset int = 0
 	var highestPointOffset int = 0
 
+	progress := outputhandler.NewProgressBar(fmt.Sprintf("%d rocks", maxShapesToFall), int64(maxShapesToFall))
+	defer progress.Finish()
+
 	shapesFallen := 0
 	haveFallingRock := false
 	for {
@@ -398,6 +456,10 @@ func letTheBlocksFall(jets string, shapeList []Shape, chamber VerticalChamber, m
 				break
 			}
 
+			if err := ctx.Err(); err != nil {
+				return highestPoint + highestPointOffset, shapesFallen + shapesFallenOffset, err
+			}
+
 			// Part 2
 			if withPatternDetection {
 
@@ -440,52 +502,92 @@ func letTheBlocksFall(jets string, shapeList []Shape, chamber VerticalChamber, m
 
 			haveFallingRock = true
 
-			//fmt.Println("new shape added")
-			//visualize(chamber.Field, shapesFallen)
+			traceMoves.Debugf("new shape added, %d fallen so far", shapesFallen+shapesFallenOffset)
+			//visualize(chamber, shapesFallen)
 		}
 
 		// apply jet
 		switch jets[currJetIdx] {
 		case byte(Left):
 			moveResult := chamber.MoveLeft()
-			_ = moveResult
-			//fmt.Printf("move shape to the left - %s\n", moveResult)
+			if traceMoves.IsEnabled(outputhandler.TraceVerbose) {
+				traceMoves.Verbosef("move shape to the left - %s", moveResult)
+			}
 
 		case byte(Right):
 			moveResult := chamber.MoveRight()
-			_ = moveResult
-			//fmt.Printf("move shape to the right - %s\n", moveResult)
+			if traceMoves.IsEnabled(outputhandler.TraceVerbose) {
+				traceMoves.Verbosef("move shape to the right - %s", moveResult)
+			}
 		}
-		//visualize(chamber.Field, shapesFallen)
+		//visualize(chamber, shapesFallen)
 		currJetIdx = (currJetIdx + 1) % len(jets)
 
 		// fall check
 		moveResult := chamber.MoveDown()
-		//fmt.Printf("move shape down - %s\n", moveResult)
+		if traceMoves.IsEnabled(outputhandler.TraceVerbose) {
+			traceMoves.Verbosef("move shape down - %s", moveResult)
+		}
 		if moveResult != Success {
 
 			chamber.Solidify()
 			haveFallingRock = false
 			shapesFallen++
-			fmt.Printf("%.2f%%\n", float64(shapesFallen+shapesFallenOffset)/float64(maxShapesToFall)*100)
+			progress.Update(int64(shapesFallen + shapesFallenOffset))
 
 			currShapeIdx = (currShapeIdx + 1) % len(ShapeList)
 		}
-		//visualize(chamber.Field, shapesFallen)
+		//visualize(chamber, shapesFallen)
 	}
 
-	//visualize(chamber.Field, shapesFallen)
-	return highestPoint + highestPointOffset
+	//visualize(chamber, shapesFallen)
+	return highestPoint + highestPointOffset, shapesFallen + shapesFallenOffset, nil
 }
 
-func visualize(lines [][]byte, blockNum int) {
+var chamberViewport = outputhandler.NewViewport()
+
+// visualize prints the part of the chamber that fits the terminal, following the falling rock.
+func visualize(chamber VerticalChamber, blockNum int) {
 	/*
 		if blockNum != maxShapesFallen-1 {
 			return
 		}
 	*/
-	for lineIdx, line := range lines {
-		fmt.Println(string(line), len(lines)-lineIdx)
+	if outputhandler.IsInteractive() {
+		exploreChamber(chamber)
+		return
+	}
+
+	lines := make([]string, len(chamber.Field))
+	for lineIdx, line := range chamber.Field {
+		lines[lineIdx] = fmt.Sprintf("%s %d", string(line), len(chamber.Field)-lineIdx)
+	}
+
+	if chamber.CurrShape != nil {
+		chamberViewport.Follow(chamber.CurrShapeLeftIdx, chamber.convertVIdxToIdx(chamber.CurrShapeBottomIdx))
+	} else {
+		chamberViewport.Follow(0, chamber.convertVPosToIdx(chamber.FindHighestBlock()))
+	}
+
+	chamberViewport.PrintFrame(lines)
+}
+
+// exploreChamber lets the user look around the chamber, starting from the top of the tower.
+func exploreChamber(chamber VerticalChamber) {
+
+	grid := &outputhandler.ExplorerGrid{
+		Width:  chamber.Width,
+		Height: len(chamber.Field),
+		Cell: func(x, y, _ int) rune {
+			return rune(chamber.Field[y][x])
+		},
+		Value: func(x, y, _ int) string {
+			return fmt.Sprintf("'%c' at height %d", chamber.Field[y][x], len(chamber.Field)-y)
+		},
+		Empty: rune(Air),
+	}
+
+	if err := outputhandler.ExploreGrid(grid, 0, chamber.convertVPosToIdx(chamber.FindHighestBlock()), 0); err != nil {
+		outputhandler.PrintError("Error while exploring the chamber", err)
 	}
-	fmt.Println()
 }
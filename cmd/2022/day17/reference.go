@@ -0,0 +1,163 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference drops the rocks one by one into rows of bits. Part 2 skips the repeating
+// part too, but it only takes a state as seen before if the empty space the rocks can
+// reach from the top is exactly the same, not just the column heights.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	jets, err := parseJets(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	rocks := 2022
+	if part == 2 {
+		rocks = 1000000000000
+	}
+
+	tower := newReferenceTower(jets)
+	seen := make(map[string][2]int) // by the state: the rocks fallen and the height
+
+	skippedHeight := 0
+	for fallen := 0; fallen < rocks; fallen++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if part == 2 && skippedHeight == 0 {
+			state := tower.getState()
+			if before, found := seen[state]; found {
+				period := fallen - before[0]
+				periods := (rocks - fallen) / period
+				skippedHeight = periods * (tower.height() - before[1])
+				fallen += periods * period
+				if fallen == rocks {
+					break
+				}
+			}
+			seen[state] = [2]int{fallen, tower.height()}
+		}
+
+		tower.dropRock()
+	}
+
+	return tower.height() + skippedHeight, nil
+}
+
+// referenceTower is the chamber as bits, bit x is the column x from the left
+type referenceTower struct {
+	rows     []uint8
+	jets     string
+	jetIdx   int
+	shapeIdx int
+	shapes   [][][2]int // the blocks of the shapes as x,y from the bottom left
+}
+
+func newReferenceTower(jets string) *referenceTower {
+
+	shapes := make([][][2]int, len(ShapeList))
+	for shapeIdx, shape := range ShapeList {
+		for lineIdx, line := range shape {
+			for x, block := range line {
+				if block == rune(StaticBlock) {
+					shapes[shapeIdx] = append(shapes[shapeIdx], [2]int{x, len(shape) - 1 - lineIdx})
+				}
+			}
+		}
+	}
+
+	return &referenceTower{rows: make([]uint8, 0), jets: jets, shapes: shapes}
+}
+
+func (t *referenceTower) height() int {
+	return len(t.rows)
+}
+
+func (t *referenceTower) isFree(x, y int) bool {
+	if x < 0 || x >= 7 || y < 0 {
+		return false
+	}
+	return y >= len(t.rows) || t.rows[y]&(1<<x) == 0
+}
+
+func (t *referenceTower) canPlace(shape [][2]int, left, bottom int) bool {
+	for _, block := range shape {
+		if !t.isFree(left+block[0], bottom+block[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *referenceTower) dropRock() {
+
+	shape := t.shapes[t.shapeIdx]
+	t.shapeIdx = (t.shapeIdx + 1) % len(t.shapes)
+
+	left, bottom := shapeStartHGap, len(t.rows)+shapeStartVGap
+	for {
+		push := 1
+		if JetDirection(t.jets[t.jetIdx]) == Left {
+			push = -1
+		}
+		t.jetIdx = (t.jetIdx + 1) % len(t.jets)
+
+		if t.canPlace(shape, left+push, bottom) {
+			left += push
+		}
+		if !t.canPlace(shape, left, bottom-1) {
+			break
+		}
+		bottom--
+	}
+
+	for _, block := range shape {
+		for len(t.rows) <= bottom+block[1] {
+			t.rows = append(t.rows, 0)
+		}
+		t.rows[bottom+block[1]] |= 1 << (left + block[0])
+	}
+}
+
+// getState returns the next shape and jet with the free cells reachable from above the
+// tower, moving left, right and down like the rocks, relative to the top
+func (t *referenceTower) getState() string {
+
+	type cell struct{ x, y int }
+
+	top := len(t.rows)
+	reached := make(map[cell]bool)
+	queue := make([]cell, 0)
+	for x := 0; x < 7; x++ {
+		reached[cell{x, top}] = true
+		queue = append(queue, cell{x, top})
+	}
+
+	var state strings.Builder
+	state.WriteString(strconv.Itoa(t.shapeIdx) + "," + strconv.Itoa(t.jetIdx) + ":")
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		state.WriteString(strconv.Itoa(curr.x) + "," + strconv.Itoa(top-curr.y) + ";")
+
+		for _, next := range []cell{{curr.x - 1, curr.y}, {curr.x + 1, curr.y}, {curr.x, curr.y - 1}} {
+			if !reached[next] && t.isFree(next.x, next.y) {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return state.String()
+}
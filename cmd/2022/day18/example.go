@@ -0,0 +1,20 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	_ "embed"
+)
+
+//go:embed example.txt
+var exampleInput string
+
+func init() {
+	inputhandler.SetExample(inputhandler.Example{
+		Input: exampleInput,
+		// the solution only counts the sides reachable from the outside, which
+		// is the answer of the puzzle's part 2 (part 1 would be 64)
+		Answers: map[string]string{
+			"Part1": "58",
+		},
+	})
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzCreate3DGridFrom checks that any list of cubes is parsed or rejected, without a panic.
+// The sides of the parsed droplet are counted too, to check that the grid holds the cubes.
+func FuzzCreate3DGridFrom(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("1,1,1\n2,1,1\n3")
+	f.Add("-1,0,0\n1,2,3,4\n300,0,0")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		grid, err := create3DGridFrom(lines)
+		parsetest.CheckError(t, err)
+		if err != nil {
+			return
+		}
+
+		countExposedSides(grid)
+	})
+}
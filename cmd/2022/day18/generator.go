@@ -0,0 +1,73 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(20, generateDroplet)
+}
+
+// generateDroplet creates a lava droplet in a size^3 box: a blob grown from the middle,
+// so it has pockets of air inside like the real ones.
+func generateDroplet(rng *rand.Rand, size int) inputhandler.Generated {
+
+	if size < 2 {
+		size = 2
+	}
+
+	type cube struct{ x, y, z int }
+	center := cube{size / 2, size / 2, size / 2}
+	cubes := map[cube]bool{center: true}
+	frontier := []cube{center}
+	target := size * size * size / 3
+	for len(cubes) < target && len(frontier) > 0 {
+		from := frontier[rng.Intn(len(frontier))]
+		next := from
+		switch rng.Intn(6) {
+		case 0:
+			next.x++
+		case 1:
+			next.x--
+		case 2:
+			next.y++
+		case 3:
+			next.y--
+		case 4:
+			next.z++
+		default:
+			next.z--
+		}
+		if next.x < 0 || next.y < 0 || next.z < 0 || next.x >= size || next.y >= size || next.z >= size {
+			continue
+		}
+		if !cubes[next] {
+			cubes[next] = true
+			frontier = append(frontier, next)
+		}
+	}
+
+	// the coordinates start from 0 on every axis
+	minX, minY, minZ := size, size, size
+	for c := range cubes {
+		if c.x < minX {
+			minX = c.x
+		}
+		if c.y < minY {
+			minY = c.y
+		}
+		if c.z < minZ {
+			minZ = c.z
+		}
+	}
+
+	lines := make([]string, 0, len(cubes))
+	for _, c := range frontier {
+		lines = append(lines, fmt.Sprintf("%d,%d,%d", c.x-minX, c.y-minY, c.z-minZ))
+	}
+	rng.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+
+	return inputhandler.Generated{Lines: lines}
+}
@@ -2,9 +2,8 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
-	"fmt"
+	"AoC22/internal/outputhandler"
 	"math"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,16 +11,33 @@ import (
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 18, "Exposed sides")
 	grid, err := create3DGridFrom(lines)
 	if err != nil {
-		fmt.Printf("Error: couldn't create grid: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		outputhandler.PrintError("Error: couldn't create grid", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	if inputhandler.IsPartRequested(1) {
+		exposedSides := countExposedSides(grid)
+
+		results.Add("Part1", exposedSides)
 	}
 
-	exposedSides := countExposedSides(grid)
+	if outputhandler.IsInteractive() {
+		exploreDroplet(grid)
+	}
 
-	fmt.Printf("Result - Part1: %d", exposedSides)
+	results.Print()
 }
 
 //-----------------------------------------------------------------------------
@@ -112,6 +128,32 @@ func countExposedSides(grid [][][]bool) int {
 	return count
 }
 
+// exploreDroplet lets the user step through the droplet one Z slice at a time.
+func exploreDroplet(grid [][][]bool) {
+
+	explorerGrid := &outputhandler.ExplorerGrid{
+		Width:  len(grid),
+		Height: len(grid[0]),
+		Depth:  len(grid[0][0]),
+		Cell: func(x, y, z int) rune {
+			if grid[x][y][z] {
+				return '#'
+			}
+			return '.'
+		},
+		Value: func(x, y, z int) string {
+			if grid[x][y][z] {
+				return "lava"
+			}
+			return "air"
+		},
+	}
+
+	if err := outputhandler.ExploreGrid(explorerGrid, 0, 0, len(grid[0][0])/2); err != nil {
+		outputhandler.PrintError("Error while exploring the droplet", err)
+	}
+}
+
 //-BFS-------------------------------------------------------------------------
 
 type PlayField struct {
@@ -210,6 +252,9 @@ func ReverseSlice[T comparable](s []T) {
 
 //-----------------------------------------------------------------------------
 
+// the droplet is kept in memory as a grid, the coordinates are limited to keep it small
+const maxGridCoord = 255
+
 func create3DGridFrom(lines []string) ([][][]bool, error) {
 
 	coordsList := make([][]int, 0, 50)
@@ -222,23 +267,23 @@ func create3DGridFrom(lines []string) ([][][]bool, error) {
 		}
 
 		coords := strings.Split(line, ",")
-		if len(coords) < 3 {
-			return nil, fmt.Errorf("too few params for a coord in '%s' at line '%d'", line, lineIdx)
+		if len(coords) != 3 {
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid param count '%d' for a coord", len(coords))
 		}
 
 		x, err := strconv.Atoi(coords[0])
-		if err != nil {
-			return nil, fmt.Errorf("couldn't parse X coord from '%s' at line '%d'", coords[0], lineIdx)
+		if err != nil || x < 0 || x > maxGridCoord {
+			return nil, inputhandler.NewParseError(lineIdx, line, 0, "couldn't parse X coord from '%s'", coords[0])
 		}
 
 		y, err := strconv.Atoi(coords[1])
-		if err != nil {
-			return nil, fmt.Errorf("couldn't parse Y coord from '%s' at line '%d'", coords[1], lineIdx)
+		if err != nil || y < 0 || y > maxGridCoord {
+			return nil, inputhandler.NewParseError(lineIdx, line, len(coords[0])+1, "couldn't parse Y coord from '%s'", coords[1])
 		}
 
 		z, err := strconv.Atoi(coords[2])
-		if err != nil {
-			return nil, fmt.Errorf("couldn't parse Z coord from '%s' at line '%d'", coords[2], lineIdx)
+		if err != nil || z < 0 || z > maxGridCoord {
+			return nil, inputhandler.NewParseError(lineIdx, line, len(coords[0])+len(coords[1])+2, "couldn't parse Z coord from '%s'", coords[2])
 		}
 
 		dims.Update(x, y, z)
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference fills the air around the droplet once, from a box one cube bigger than
+// the grid, and counts the cube sides the air touches. No BFS per side.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	if part != 1 {
+		return nil, inputhandler.ErrorNoReference // the day only solves the exterior sides
+	}
+
+	grid, err := create3DGridFrom(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	type cube struct{ x, y, z int }
+	sizeX, sizeY, sizeZ := len(grid), len(grid[0]), len(grid[0][0])
+	isLava := func(c cube) bool {
+		if c.x < 0 || c.y < 0 || c.z < 0 || c.x >= sizeX || c.y >= sizeY || c.z >= sizeZ {
+			return false
+		}
+		return grid[c.x][c.y][c.z]
+	}
+	isInBox := func(c cube) bool {
+		return c.x >= -1 && c.y >= -1 && c.z >= -1 && c.x <= sizeX && c.y <= sizeY && c.z <= sizeZ
+	}
+
+	start := cube{-1, -1, -1}
+	air := map[cube]bool{start: true}
+	queue := []cube{start}
+	sides := 0
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		curr := queue[0]
+		queue = queue[1:]
+
+		for _, next := range []cube{
+			{curr.x + 1, curr.y, curr.z}, {curr.x - 1, curr.y, curr.z},
+			{curr.x, curr.y + 1, curr.z}, {curr.x, curr.y - 1, curr.z},
+			{curr.x, curr.y, curr.z + 1}, {curr.x, curr.y, curr.z - 1},
+		} {
+			switch {
+			case isLava(next):
+				sides++
+			case isInBox(next) && !air[next]:
+				air[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return sides, nil
+}
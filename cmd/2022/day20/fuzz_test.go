@@ -0,0 +1,40 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseCoords checks that any encrypted file is parsed or rejected, without a panic.
+// The parsed numbers are mixed too, with and without the decryption key.
+func FuzzParseCoords(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("0")
+	f.Add("1\n\n0\n-3")
+	f.Add("0\n99999999999\nx")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		coordList, err := parseCoords(lines)
+		parsetest.CheckError(t, err)
+		if err != nil {
+			return
+		}
+
+		mixedCoords := mix(append([]Coord{}, coordList...))
+		if zeroIdx, found := getIdxOf(0, mixedCoords); found {
+			sumCoords([]int{1000, 2000, 3000}, zeroIdx, mixedCoords)
+		} else {
+			t.Fatalf("the 0 is lost after mixing '%v'", coordList)
+		}
+
+		moddedCoordList := make([]Coord, len(coordList))
+		for coordIdx, coord := range coordList {
+			moddedCoordList[coordIdx] = Coord{Value: MulInt(coord.Value, 811589153), Idx: coord.Idx}
+		}
+		mix(moddedCoordList)
+	})
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"math/rand"
+	"strconv"
+)
+
+func init() {
+	inputhandler.SetGenerator(5000, generateEncryptedFile)
+}
+
+// generateEncryptedFile creates size numbers, duplicates included, with exactly one 0
+func generateEncryptedFile(rng *rand.Rand, size int) inputhandler.Generated {
+
+	if size < 1 {
+		size = 1
+	}
+
+	lines := make([]string, size)
+	for idx := range lines {
+		value := 0
+		for value == 0 {
+			value = rng.Intn(20001) - 10000
+		}
+		lines[idx] = strconv.Itoa(value)
+	}
+	lines[rng.Intn(size)] = "0"
+
+	return inputhandler.Generated{Lines: lines}
+}
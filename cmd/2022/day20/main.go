@@ -0,0 +1,193 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type Coord struct {
+	Value int
+	Idx   int
+}
+
+func MulInt(val1, val2 int) int {
+	res := val1 * val2
+	if (res < 0) == ((val1 < 0) != (val2 < 0)) {
+		if res/val2 == val1 {
+			return res
+		}
+	}
+	panic("multiplication overflow")
+}
+
+func main() {
+
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
+	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 20, "Grove coordinates")
+
+	coordList, err := parseCoords(lines)
+	if err != nil {
+		outputhandler.PrintError("Error parsing input", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+
+	if results.SolveReference(lines) {
+		results.Print()
+		return
+	}
+
+	// Part 1
+
+	if inputhandler.IsPartRequested(1) {
+		mixedCoords := mix(append([]Coord{}, coordList...))
+
+		zeroIdx, found := getIdxOf(0, mixedCoords)
+		if !found {
+			fmt.Printf("Error: couldn't find starting index '0' in '%v'\n", mixedCoords)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		resultPart1 := sumCoords([]int{1000, 2000, 3000}, zeroIdx, mixedCoords)
+		results.Add("Part1", resultPart1)
+	}
+
+	// Part 2
+
+	if inputhandler.IsPartRequested(2) {
+		moddedCoordList := make([]Coord, len(coordList))
+		for coordIdx, coord := range coordList {
+			moddedCoordList[coordIdx] = Coord{Value: MulInt(coord.Value, 811589153), Idx: coord.Idx}
+		}
+
+		// a round takes a while with the real input, so the run can be stopped between them
+		ctx := inputhandler.GetContext()
+		mixedCoords := moddedCoordList
+		var mixCount int
+		for mixCount = 0; mixCount < 10 && ctx.Err() == nil; mixCount++ {
+			mixedCoords = mix(mixedCoords)
+		}
+
+		if mixCount < 10 {
+			results.AddStopped("Part2", fmt.Sprintf("%d of %d mixing rounds done", mixCount, 10))
+		} else {
+			zeroIdx, found := getIdxOf(0, mixedCoords)
+			if !found {
+				fmt.Printf("Error: couldn't find starting index '0' in '%v'\n", mixedCoords)
+				inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+			}
+
+			resultPart2 := sumCoords([]int{1000, 2000, 3000}, zeroIdx, mixedCoords)
+
+			results.Add("Part2", resultPart2)
+		}
+	}
+
+	results.Print()
+}
+
+//-----------------------------------------------------------------------------
+
+// the biggest value that can be multiplied with the decryption key of part 2
+const maxCoordValue = math.MaxInt / 811589153
+
+func parseCoords(lines []string) ([]Coord, error) {
+
+	coords := make([]Coord, 0, len(lines))
+	var zeroCount int
+	for lineIdx, line := range lines {
+
+		if len(line) == 0 {
+			continue
+		}
+
+		val, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, inputhandler.NewParseError(lineIdx, line, 0, "couldn't convert '%s'", line)
+		}
+		if val > maxCoordValue || val < -maxCoordValue {
+			return nil, inputhandler.NewParseError(lineIdx, line, 0, "value '%d' is too big for the decryption key", val)
+		}
+		if val == 0 {
+			zeroCount++
+		}
+
+		// the index is the order of the mixing, so the empty lines are not counted
+		coords = append(coords, Coord{Value: val, Idx: len(coords)})
+	}
+
+	// the coordinates are counted from the 0, mixing needs it to be there once
+	if zeroCount != 1 {
+		return nil, inputhandler.NewParseError(-1, "", -1, "expected exactly one '0' value, found %d", zeroCount)
+	}
+
+	return coords, nil
+}
+
+//-----------------------------------------------------------------------------
+
+var traceMixing = outputhandler.NewTracer("day20", "mixing")
+
+func sumCoords(posList []int, zeroIdx int, mixedCoords []Coord) int {
+
+	var result int
+	for _, pos := range posList {
+		cordAtPos := mixedCoords[(pos+zeroIdx)%len(mixedCoords)].Value
+		traceMixing.Debugf("%dth - %d", pos, cordAtPos)
+
+		result += cordAtPos
+	}
+
+	return result
+}
+
+func mix(coords []Coord) []Coord {
+
+	for currCoordIdx := 0; currCoordIdx < len(coords); currCoordIdx++ {
+
+		var newCoords []Coord
+		for idx, coord := range coords {
+			if coord.Idx != currCoordIdx {
+				continue
+			}
+
+			ringCoords := append(coords[:idx], coords[idx+1:]...)
+
+			var moveToIdx int
+			if coord.Value > 0 {
+				moveToIdx = (idx + (coord.Value % len(ringCoords))) % len(ringCoords)
+			} else if coord.Value < 0 {
+				moveToIdx = len(ringCoords) - (((len(ringCoords) - idx) - (coord.Value % len(ringCoords))) % len(ringCoords))
+			} else {
+				moveToIdx = idx
+			}
+
+			newCoords = append([]Coord{}, ringCoords[:moveToIdx]...)
+			newCoords = append(newCoords, coord)
+			newCoords = append(newCoords, ringCoords[moveToIdx:]...)
+
+			if traceMixing.IsEnabled(outputhandler.TraceVerbose) {
+				traceMixing.Verbosef("moving: '%d' - %v", coord.Value, newCoords)
+			}
+			break
+		}
+
+		coords = newCoords
+	}
+
+	return coords
+}
+
+func getIdxOf(coord int, coordList []Coord) (int, bool) {
+	for idx, currCoord := range coordList {
+		if currCoord.Value == coord {
+			return idx, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"fmt"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference keeps the numbers in a circular linked list and moves each one by
+// swapping it with its neighbour, one step at a time. The full turns around the other
+// numbers are skipped, they don't change the order.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	coordList, err := parseCoords(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	count := len(coordList)
+	values := make([]int, count)
+	for idx, coord := range coordList {
+		values[idx] = coord.Value
+	}
+
+	rounds := 1
+	if part == 2 {
+		rounds = 10
+		for idx := range values {
+			values[idx] = MulInt(values[idx], 811589153)
+		}
+	}
+
+	next := make([]int, count)
+	prev := make([]int, count)
+	for idx := range values {
+		next[idx] = (idx + 1) % count
+		prev[idx] = (idx + count - 1) % count
+	}
+
+	// moveAfter takes the number out of the list and puts it back after the other one
+	moveAfter := func(idx, after int) {
+		next[prev[idx]], prev[next[idx]] = next[idx], prev[idx]
+		prev[idx], next[idx] = after, next[after]
+		prev[next[after]], next[after] = idx, idx
+	}
+
+	for round := 0; round < rounds; round++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for idx, value := range values {
+			if count < 2 {
+				break
+			}
+
+			steps := value % (count - 1)
+			for ; steps > 0; steps-- {
+				moveAfter(idx, next[idx])
+			}
+			for ; steps < 0; steps++ {
+				moveAfter(idx, prev[prev[idx]])
+			}
+		}
+	}
+
+	zeroIdx := -1
+	for idx, value := range values {
+		if value == 0 {
+			zeroIdx = idx
+		}
+	}
+	if zeroIdx < 0 {
+		return nil, fmt.Errorf("couldn't find the number '0'")
+	}
+
+	sum := 0
+	for _, pos := range []int{1000, 2000, 3000} {
+		idx := zeroIdx
+		for step := 0; step < pos%count; step++ {
+			idx = next[idx]
+		}
+		sum += values[idx]
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzParseMonkeys checks that any list of monkey jobs is parsed or rejected, without a panic.
+func FuzzParseMonkeys(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Add("root: aaaa + bbbb\naaaa: 5\nbbbb: x")
+	f.Add("root: aaaa % bbbb\naaaa: -5\naaaa: 5\n: 1")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := parseMonkeys(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"math/rand"
+)
+
+func init() {
+	inputhandler.SetGenerator(2000, generateMonkeys)
+}
+
+// the values stay far from overflowing, AddInt() and friends panic on it
+const generatedValueLimit = 1_000_000_000
+const generatedPathLimit = 1_000_000_000_000
+
+// monkeyGenerator writes the jobs of the monkeys with unique names
+type monkeyGenerator struct {
+	rng   *rand.Rand
+	lines []string
+	names map[string]bool
+}
+
+// generateMonkeys creates about size monkeys. Like in the puzzle, only one side of root
+// depends on humn, through additions, subtractions and multiplications, and every
+// division is exact. The other side of root gets a number that makes part 2 solvable.
+func generateMonkeys(rng *rand.Rand, size int) inputhandler.Generated {
+
+	g := &monkeyGenerator{
+		rng:   rng,
+		lines: make([]string, 0, size),
+		names: map[string]bool{"root": true, "humn": true},
+	}
+
+	depth := size / 40
+	if depth < 1 {
+		depth = 1
+	}
+	if depth > 60 {
+		depth = 60
+	}
+	budget := size - 2*depth - 3 // the path, root, humn and the other side's leaf
+	if budget < depth+1 {
+		budget = depth + 1
+	}
+
+	// the path is evaluated with both my input value and the answer of part 2
+	myValue := 1 + rng.Intn(5000)
+	answer := 1 + rng.Intn(1_000_000)
+	pathName, pathInput, pathAnswer := "humn", myValue, answer
+	g.lines = append(g.lines, fmt.Sprintf("humn: %d", myValue))
+
+	for level := 0; level < depth; level++ {
+
+		name := g.newName()
+		var offName string
+		var offValue int
+		var op Operation
+
+		switch g.rng.Intn(3) {
+		case 0:
+			if abs(pathInput) < generatedPathLimit/10 && abs(pathAnswer) < generatedPathLimit/10 {
+				offValue = 2 + g.rng.Intn(8)
+				offName = g.addNumber(offValue)
+				op = Multiplication
+				break
+			}
+			fallthrough
+		case 1:
+			offName, offValue = g.addTree(budget / (depth + 1))
+			op = Addition
+		default:
+			offName, offValue = g.addTree(budget / (depth + 1))
+			op = Subtraction
+		}
+
+		// the path is either operand
+		if g.rng.Intn(2) == 0 {
+			g.lines = append(g.lines, fmt.Sprintf("%s: %s %s %s", name, pathName, op, offName))
+			pathInput, pathAnswer = applyOperation(op, pathInput, offValue), applyOperation(op, pathAnswer, offValue)
+		} else {
+			g.lines = append(g.lines, fmt.Sprintf("%s: %s %s %s", name, offName, op, pathName))
+			pathInput, pathAnswer = applyOperation(op, offValue, pathInput), applyOperation(op, offValue, pathAnswer)
+		}
+		pathName = name
+	}
+
+	// the other side is a tree adjusted to the value the answer gives
+	treeName, treeValue := g.addTree(budget / (depth + 1))
+	otherName := g.newName()
+	if pathAnswer >= treeValue { // the numbers can't be negative, "-" is an operation
+		g.lines = append(g.lines, fmt.Sprintf("%s: %s + %s", otherName, treeName, g.addNumber(pathAnswer-treeValue)))
+	} else {
+		g.lines = append(g.lines, fmt.Sprintf("%s: %s - %s", otherName, treeName, g.addNumber(treeValue-pathAnswer)))
+	}
+
+	if g.rng.Intn(2) == 0 {
+		g.lines = append(g.lines, fmt.Sprintf("root: %s + %s", pathName, otherName))
+	} else {
+		g.lines = append(g.lines, fmt.Sprintf("root: %s + %s", otherName, pathName))
+	}
+
+	g.rng.Shuffle(len(g.lines), func(i, j int) { g.lines[i], g.lines[j] = g.lines[j], g.lines[i] })
+
+	return inputhandler.Generated{Lines: g.lines}
+}
+
+// newName returns an unused name of four letters
+func (g *monkeyGenerator) newName() string {
+	for {
+		name := make([]byte, 4)
+		for idx := range name {
+			name[idx] = byte('a' + g.rng.Intn(26))
+		}
+		if !g.names[string(name)] {
+			g.names[string(name)] = true
+			return string(name)
+		}
+	}
+}
+
+func (g *monkeyGenerator) addNumber(value int) string {
+	name := g.newName()
+	g.lines = append(g.lines, fmt.Sprintf("%s: %d", name, value))
+	return name
+}
+
+// addTree adds a tree of about budget monkeys and returns its root and value
+func (g *monkeyGenerator) addTree(budget int) (string, int) {
+
+	if budget < 3 {
+		value := 1 + g.rng.Intn(20)
+		return g.addNumber(value), value
+	}
+
+	name := g.newName()
+
+	// a division needs a dividend that is a multiple: (tree * k) / k
+	if budget >= 5 && g.rng.Intn(4) == 0 {
+		treeName, treeValue := g.addTree(budget - 4)
+		divisor := 2 + g.rng.Intn(8)
+		if abs(treeValue) < generatedValueLimit/10 {
+			productName := g.newName()
+			g.lines = append(g.lines, fmt.Sprintf("%s: %s * %s", productName, treeName, g.addNumber(divisor)))
+			g.lines = append(g.lines, fmt.Sprintf("%s: %s / %s", name, productName, g.addNumber(divisor)))
+			return name, treeValue
+		}
+		g.lines = append(g.lines, fmt.Sprintf("%s: %s + %s", name, treeName, g.addNumber(divisor)))
+		return name, treeValue + divisor
+	}
+
+	leftBudget := 1 + g.rng.Intn(budget-2)
+	leftName, leftValue := g.addTree(leftBudget)
+	rightName, rightValue := g.addTree(budget - 1 - leftBudget)
+
+	op := [...]Operation{Addition, Subtraction, Multiplication}[g.rng.Intn(3)]
+	if op == Multiplication && (leftValue == 0 || rightValue == 0 || abs(leftValue) >= generatedValueLimit/abs(rightValue)) {
+		op = Addition
+	}
+	value := applyOperation(op, leftValue, rightValue)
+	if abs(value) >= generatedValueLimit {
+		// one of them shrinks, the operands are below the limit
+		if op == Addition {
+			op = Subtraction
+		} else {
+			op = Addition
+		}
+		value = applyOperation(op, leftValue, rightValue)
+	}
+	g.lines = append(g.lines, fmt.Sprintf("%s: %s %s %s", name, leftName, op, rightName))
+
+	return name, value
+}
+
+// applyOperation calculates the value like the monkey would
+func applyOperation(op Operation, val1, val2 int) int {
+
+	job := NewMathOperation("", "", op)
+	job.SetFirstOperand(val1)
+	job.SetSecondOperand(val2)
+	value, _ := job.Solve()
+
+	return value
+}
+
+func abs(val int) int {
+	if val < 0 {
+		return -val
+	}
+	return val
+}
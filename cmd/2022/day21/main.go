@@ -2,8 +2,8 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 )
@@ -210,34 +210,52 @@ func DivInt(val1, val2 int) int {
 
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 21, "Result")
 
 	monkeys, err := parseMonkeys(lines)
 	if err != nil {
-		fmt.Printf("Error parsing monkeys: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+		outputhandler.PrintError("Error parsing monkeys", err)
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
 	}
+	//visualizeMonkeys(monkeys, 8)
 
-	resultPart1, err := resolveMonkeyEquations(monkeys)
-	if err != nil {
-		fmt.Printf("Error resolving equations: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if results.SolveReference(lines) {
+		results.Print()
+		return
 	}
 
-	// TODO: ugly, but time...
-	monkeys, err = parseMonkeys(lines)
-	if err != nil {
-		fmt.Printf("Error parsing monkeys: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(1) {
+		resultPart1, err := resolveMonkeyEquations(monkeys)
+		if err != nil {
+			outputhandler.PrintError("Error resolving equations", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part1", resultPart1)
 	}
 
-	resultPart2, err := findMyAnswer(monkeys)
-	if err != nil {
-		fmt.Printf("Error finding my answer: %v", err)
-		os.Exit(int(inputhandler.ErrorCodeProcessing))
+	if inputhandler.IsPartRequested(2) {
+		// TODO: ugly, but time...
+		monkeys, err = parseMonkeys(lines)
+		if err != nil {
+			outputhandler.PrintError("Error parsing monkeys", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		resultPart2, err := findMyAnswer(monkeys)
+		if err != nil {
+			outputhandler.PrintError("Error finding my answer", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", resultPart2)
 	}
 
-	fmt.Printf("Result - Part1: %d, Part2: %d", resultPart1, resultPart2)
+	results.Print()
 }
 
 //-----------------------------------------------------------------------------
@@ -245,6 +263,7 @@ func main() {
 func parseMonkeys(lines []string) (map[string]*Monkey, error) {
 
 	monkeys := make(map[string]*Monkey)
+	jobLineIdxs := make([]int, 0) // to report the unknown operands in order
 	for lineIdx, line := range lines {
 
 		if len(line) == 0 {
@@ -253,7 +272,7 @@ func parseMonkeys(lines []string) (map[string]*Monkey, error) {
 
 		keyval := strings.Split(line, ":")
 		if len(keyval) != 2 {
-			return nil, fmt.Errorf("invalid key-value format '%s' at line %d", line, lineIdx+1)
+			return nil, inputhandler.NewParseError(lineIdx, line, -1, "invalid key-value format")
 		}
 
 		mName := strings.TrimSpace(keyval[0])
@@ -263,34 +282,104 @@ func parseMonkeys(lines []string) (map[string]*Monkey, error) {
 
 			equation := strings.Split(strings.TrimSpace(keyval[1]), " ")
 			if len(equation) != 3 {
-				return nil, fmt.Errorf("invalid equation '%s' at line %d", keyval[1], lineIdx+1)
+				return nil, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 1), "invalid equation '%s'", strings.TrimSpace(keyval[1]))
+			}
+
+			if len(equation[1]) != 1 || !strings.Contains(OpList, equation[1]) {
+				return nil, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 2), "%w '%s'", ErrorInvalidOperation, equation[1])
 			}
 
 			job := NewMathOperation(equation[0], equation[2], Operation(equation[1]))
 			monkey.SetOperation(*job)
+			jobLineIdxs = append(jobLineIdxs, lineIdx)
 
 		} else { // invalid operations will fail here
 
 			val, err := strconv.Atoi(strings.TrimSpace(keyval[1]))
 			if err != nil {
-				return nil, fmt.Errorf("couldn't parse numeric value from '%s' at line %d", keyval[1], lineIdx+1)
+				return nil, inputhandler.NewParseError(lineIdx, line, inputhandler.FieldColumn(line, 1), "couldn't parse numeric value from '%s'", strings.TrimSpace(keyval[1]))
 			}
 
 			monkey.SetValue(val)
 		}
 
 		if _, found := monkeys[mName]; found {
-			return nil, fmt.Errorf("duplicate entry for monkey '%s' found at line %d", mName, lineIdx+1)
+			return nil, inputhandler.NewParseError(lineIdx, line, 0, "duplicate entry for monkey '%s'", mName)
 		}
 
 		monkeys[mName] = monkey
 	}
 
+	for _, lineIdx := range jobLineIdxs {
+		mName := strings.TrimSpace(strings.Split(lines[lineIdx], ":")[0])
+		for fieldIdx, operandRef := range []string{monkeys[mName].Job.Val1Ref, monkeys[mName].Job.Val2Ref} {
+			if _, found := monkeys[operandRef]; !found {
+				return nil, inputhandler.NewParseError(lineIdx, lines[lineIdx], inputhandler.FieldColumn(lines[lineIdx], 1+fieldIdx*2), "unknown monkey '%s' referenced by '%s'", operandRef, mName)
+			}
+		}
+	}
+
 	return monkeys, nil
 }
 
 //-----------------------------------------------------------------------------
 
+// monkeyNode makes the monkey equations printable by outputhandler.PrintTree
+type monkeyNode struct {
+	monkey  *Monkey
+	monkeys map[string]*Monkey
+}
+
+func (mn monkeyNode) Label() string {
+	return mn.monkey.Name
+}
+
+func (mn monkeyNode) Children() []monkeyNode {
+
+	if !mn.monkey.HasJob() {
+		return nil
+	}
+
+	children := make([]monkeyNode, 0, 2)
+	for _, ref := range []string{mn.monkey.Job.Val1Ref, mn.monkey.Job.Val2Ref} {
+		if child, found := mn.monkeys[ref]; found {
+			children = append(children, monkeyNode{monkey: child, monkeys: mn.monkeys})
+		}
+	}
+	return children
+}
+
+// visualizeMonkeys prints the equation tree from the root monkey down to maxDepth
+func visualizeMonkeys(monkeys map[string]*Monkey, maxDepth int) {
+
+	rootMonkey, found := monkeys["root"]
+	if !found {
+		return
+	}
+
+	outputhandler.PrintTree(monkeyNode{monkey: rootMonkey, monkeys: monkeys}, outputhandler.TreeOptions[monkeyNode]{
+		MaxDepth:  maxDepth,
+		TreeColor: outputhandler.Gray,
+		Color: func(node monkeyNode) outputhandler.TerminalColor {
+			switch node.monkey.Name {
+			case "root":
+				return outputhandler.BrightYellow
+			case "humn":
+				return outputhandler.BrightRed
+			}
+			return outputhandler.BrightCyan
+		},
+		Annotate: func(node monkeyNode) (string, outputhandler.TerminalColor) {
+			if node.monkey.HasJob() {
+				return fmt.Sprintf("%s %s %s", node.monkey.Job.Val1Ref, node.monkey.Job.Op, node.monkey.Job.Val2Ref), outputhandler.DarkGray
+			}
+			return strconv.Itoa(node.monkey.Value), outputhandler.BrightGreen
+		},
+	})
+}
+
+//-----------------------------------------------------------------------------
+
 // Part 1
 func resolveMonkeyEquations(monkeys map[string]*Monkey) (int, error) {
 
@@ -423,6 +512,16 @@ func findMyAnswer(monkeys map[string]*Monkey) (int, error) {
 		}
 	}
 
+	// the other operands on my side are needed for the reverse resolve, they can be
+	// deeper than the other side of root. The extra pass hands the last ones over.
+	for resolved := false; !resolved; {
+		resolved = isResolvedExcept(monkeys, mySide)
+		err = resolvePassWithSkips(monkeys, myName)
+		if err != nil {
+			return 0, err
+		}
+	}
+
 	// setup for reverse resolve
 	var mySideRootMonkey *Monkey
 	if val1Monkey, found := mySide[rootMonkey.Job.Val1Ref]; found {
@@ -456,6 +555,16 @@ func findMyAnswer(monkeys map[string]*Monkey) (int, error) {
 	return myValue, nil
 }
 
+// isResolvedExcept tells if all the monkeys have values, except the skipped ones
+func isResolvedExcept(monkeys map[string]*Monkey, skips map[string]*Monkey) bool {
+	for name, monkey := range monkeys {
+		if _, found := skips[name]; !found && !monkey.HasValue() {
+			return false
+		}
+	}
+	return true
+}
+
 func resolvePassWithSkips(monkeys map[string]*Monkey, skipName string) error {
 
 	isChanged := false
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	"context"
+	"fmt"
+	"math/big"
+)
+
+func init() {
+	inputhandler.SetReference(solveReference)
+}
+
+// solveReference evaluates the monkeys recursively with exact fractions. For part 2 the
+// sides of root are evaluated with two values for humn, a side is a line in humn as it
+// only yells once, and where the lines meet is the answer. Nothing is undone.
+func solveReference(ctx context.Context, lines []string, part int) (interface{}, error) {
+
+	monkeys, err := parseMonkeys(lines)
+	if err != nil {
+		return nil, err
+	}
+	rootMonkey, found := monkeys["root"]
+	if !found || !rootMonkey.HasJob() {
+		return nil, fmt.Errorf("missing root monkey")
+	}
+
+	var evaluate func(name string, humn *big.Rat, depth int) (*big.Rat, error)
+	evaluate = func(name string, humn *big.Rat, depth int) (*big.Rat, error) {
+
+		monkey, found := monkeys[name]
+		switch {
+		case !found:
+			return nil, fmt.Errorf("unknown monkey '%s'", name)
+		case depth > len(monkeys):
+			return nil, fmt.Errorf("monkey '%s' depends on itself", name)
+		case name == "humn" && humn != nil:
+			return humn, nil
+		case !monkey.HasJob():
+			return new(big.Rat).SetInt64(int64(monkey.Value)), nil
+		}
+
+		val1, err := evaluate(monkey.Job.Val1Ref, humn, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		val2, err := evaluate(monkey.Job.Val2Ref, humn, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		switch monkey.Job.Op {
+		case Addition:
+			return new(big.Rat).Add(val1, val2), nil
+		case Subtraction:
+			return new(big.Rat).Sub(val1, val2), nil
+		case Multiplication:
+			return new(big.Rat).Mul(val1, val2), nil
+		case Division:
+			if val2.Sign() == 0 {
+				return nil, fmt.Errorf("monkey '%s' divides by zero", name)
+			}
+			return new(big.Rat).Quo(val1, val2), nil
+		}
+		return nil, fmt.Errorf("monkey '%s': %w '%s'", name, ErrorInvalidOperation, monkey.Job.Op)
+	}
+
+	if part == 1 {
+		value, err := evaluate("root", nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		return toInt(value)
+	}
+
+	// the difference of the sides is a line: diff(humn) = diff(0) + slope*humn
+	getDiff := func(humn int64) (*big.Rat, error) {
+		val1, err := evaluate(rootMonkey.Job.Val1Ref, big.NewRat(humn, 1), 0)
+		if err != nil {
+			return nil, err
+		}
+		val2, err := evaluate(rootMonkey.Job.Val2Ref, big.NewRat(humn, 1), 0)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Rat).Sub(val1, val2), nil
+	}
+
+	diffs := make([]*big.Rat, 3)
+	for humn := range diffs {
+		if diffs[humn], err = getDiff(int64(humn)); err != nil {
+			return nil, err
+		}
+	}
+	slope := new(big.Rat).Sub(diffs[1], diffs[0])
+	if slope.Sign() == 0 {
+		return nil, fmt.Errorf("the sides of root don't depend on humn")
+	}
+	if new(big.Rat).Sub(diffs[2], diffs[1]).Cmp(slope) != 0 {
+		return nil, fmt.Errorf("the sides of root are not lines in humn")
+	}
+
+	return toInt(new(big.Rat).Quo(new(big.Rat).Neg(diffs[0]), slope))
+}
+
+func toInt(value *big.Rat) (int, error) {
+	if !value.IsInt() || !value.Num().IsInt64() {
+		return 0, fmt.Errorf("the answer '%s' is not an integer", value.RatString())
+	}
+	return int(value.Num().Int64()), nil
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"AoC22/internal/runner"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// runAll builds and runs the days in parallel and prints the calendar with the stars.
+// Fails if any answer regressed or any day failed, days without input are skipped.
+func runAll(args []string) int {
+
+	flags := newFlagSet("all")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of days to run at the same time")
+	dayTimeout := flags.Duration("timeout", 0, "stop each day after the `duration`, like 30s (default no limit)")
+	accept := flags.Bool("accept", false, "save the answers as the verified ones, for the days without regressions")
+	example := flags.Bool("example", false, "solve the worked examples and check their answers instead")
+	if err := flags.Parse(args); err != nil {
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	days, err := calendar.ParseDays(flags.Args())
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	r, err := runner.NewRunner()
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeFiles)
+	}
+	defer r.Close()
+	r.HistoryPath, r.Command = filepath.Join(r.Root, runner.HistoryPath), "all"
+
+	if *dayTimeout > 0 {
+		r.Args = append(r.Args, "-timeout", dayTimeout.String())
+	}
+	if *example && *accept {
+		fmt.Println("Error: the answers of the examples can't be accepted")
+		return int(inputhandler.ErrorCodeParameters)
+	}
+	r.Example = *example
+
+	if err := buildDays(r, fmt.Sprintf("Building %d days", len(days)), days); err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeProcessing)
+	}
+
+	// the workers report to the progress bar one at a time
+	var progressMutex sync.Mutex
+	progress := outputhandler.NewProgressBar("Running", int64(len(days)))
+	r.OnRunDone = func(run runner.DayRun) {
+		progressMutex.Lock()
+		defer progressMutex.Unlock()
+		progress.Increment()
+	}
+
+	start := time.Now()
+	runs := r.RunAll(inputhandler.GetContext(), days, *workers)
+	progress.Finish()
+
+	summary := newCalendarSummary(r.Root, *example)
+	for _, run := range runs {
+		summary.Add(run)
+	}
+	summary.Print(time.Since(start))
+
+	if *accept {
+		acceptAnswers(summary)
+	}
+
+	if summary.Regressed > 0 || summary.Failed > 0 {
+		return int(inputhandler.ErrorCodeProcessing)
+	}
+
+	return 0
+}
+
+// acceptAnswers saves the answers of the days that ran without problems
+func acceptAnswers(summary *calendarSummary) {
+
+	saved := 0
+	for _, run := range summary.Runs {
+		if run.Err != nil || run.Stopped || run.Record == nil || summary.IsRegressed(run.Day) {
+			continue
+		}
+		path := filepath.Join(summary.root, run.Day.AnswersPath())
+		if err := runner.SaveAnswers(path, run.Record); err != nil {
+			outputhandler.PrintError("Error", err)
+			continue
+		}
+		saved++
+	}
+
+	fmt.Printf("Saved the answers of %d days\n", saved)
+}
+
+//-Summary---------------------------------------------------------------------
+
+// calendarSummary collects the runs and counts the stars
+type calendarSummary struct {
+	Runs      []runner.DayRun
+	Stars     int
+	Regressed int
+	Stopped   int // parts stopped by a timeout or Ctrl-C, neither a star nor a regression
+	Failed    int
+	Skipped   int // no input
+
+	root          string                       // of the module, the verified answers are relative to it
+	example       bool                         // the answers are checked against the worked examples
+	tables        map[int]*outputhandler.Table // one calendar per year
+	years         []int                        // of the tables, in order
+	regressedDays map[string]bool              // by the ID of the day
+}
+
+func newCalendarSummary(root string, example bool) *calendarSummary {
+	return &calendarSummary{
+		Runs:          make([]runner.DayRun, 0),
+		root:          root,
+		example:       example,
+		tables:        make(map[int]*outputhandler.Table),
+		years:         make([]int, 0),
+		regressedDays: make(map[string]bool),
+	}
+}
+
+// getTable returns the calendar table of the year
+func (s *calendarSummary) getTable(year int) *outputhandler.Table {
+
+	if table, ok := s.tables[year]; ok {
+		return table
+	}
+
+	table := outputhandler.NewTable(fmt.Sprintf("Advent of Code %d", year),
+		outputhandler.TableColumn{Header: "Day", Align: outputhandler.AlignRight},
+		outputhandler.TableColumn{Header: "Title"},
+		outputhandler.TableColumn{Header: "Part 1", Align: outputhandler.AlignRight, Color: outputhandler.BrightGreen},
+		outputhandler.TableColumn{Header: "Part 2", Align: outputhandler.AlignRight, Color: outputhandler.BrightGreen},
+		outputhandler.TableColumn{Header: "Time", Align: outputhandler.AlignRight, Color: outputhandler.Gray},
+		outputhandler.TableColumn{Header: "Stars", Align: outputhandler.AlignCenter, Color: outputhandler.BrightYellow},
+	)
+	s.tables[year] = table
+	s.years = append(s.years, year)
+	sort.Ints(s.years)
+
+	return table
+}
+
+// Add checks the run's answers against the verified ones and adds it to the table.
+func (s *calendarSummary) Add(run runner.DayRun) {
+
+	s.Runs = append(s.Runs, run)
+	table := s.getTable(run.Day.Year)
+
+	if errors.Is(run.Err, runner.ErrorNoInput) {
+		s.Skipped++
+		table.AddRow(run.Day.Number, run.Day.Title, fmt.Sprintf("no input, add %s", run.Day.InputPath()), "", "", "")
+		return
+	}
+
+	if run.Err != nil || run.Record == nil {
+		s.Failed++
+		message := "stopped before any results"
+		if run.Err != nil {
+			message = run.Err.Error()
+		}
+		table.AddRow(run.Day.Number, run.Day.Title, message, "", outputhandler.FormatElapsed(run.Elapsed), "")
+		return
+	}
+
+	var answers *runner.Answers
+	if !s.example {
+		var err error
+		if answers, err = runner.LoadAnswers(filepath.Join(s.root, run.Day.AnswersPath())); err != nil {
+			outputhandler.PrintError("Warning", err)
+		}
+	}
+
+	cells := []string{"", ""}
+	stars := ""
+	var elapsed time.Duration
+	for _, part := range run.Record.Results {
+
+		partIdx := 0
+		if part.Part == "Part2" {
+			partIdx = 1
+		}
+
+		cells[partIdx] = formatPartAnswer(part)
+		elapsed += time.Duration(part.ElapsedNs)
+
+		verdict := answers.Check(run.Record, part)
+		if s.example {
+			verdict = runner.CheckExample(part)
+		}
+
+		switch verdict {
+		case runner.Verified:
+			s.Stars++
+			stars += getStarMark()
+		case runner.Regressed:
+			s.Regressed++
+			s.regressedDays[run.Day.ID()] = true
+			stars += getRegressedMark()
+		case runner.Stopped:
+			s.Stopped++
+			stars += getStoppedMark()
+		default:
+			stars += getUnverifiedMark()
+		}
+	}
+
+	table.AddRow(run.Day.Number, run.Day.Title, cells[0], cells[1], outputhandler.FormatElapsed(elapsed), stars)
+}
+
+// IsRegressed tells if any answer of the day differs from the verified one.
+func (s *calendarSummary) IsRegressed(day calendar.Day) bool {
+	return s.regressedDays[day.ID()]
+}
+
+// Print prints the tables of the years with the totals.
+func (s *calendarSummary) Print(elapsed time.Duration) {
+
+	for _, year := range s.years {
+		s.tables[year].Print()
+	}
+
+	totals := []string{fmt.Sprintf("%d stars", s.Stars)}
+	if s.Regressed > 0 {
+		totals = append(totals, outputhandler.GetForeground(outputhandler.BrightRed)+fmt.Sprintf("%d regressed", s.Regressed)+outputhandler.GetReset())
+	}
+	if s.Stopped > 0 {
+		totals = append(totals, outputhandler.GetForeground(outputhandler.BrightYellow)+fmt.Sprintf("%d stopped", s.Stopped)+outputhandler.GetReset())
+	}
+	if s.Failed > 0 {
+		totals = append(totals, outputhandler.GetForeground(outputhandler.BrightRed)+fmt.Sprintf("%d failed", s.Failed)+outputhandler.GetReset())
+	}
+	if s.Skipped > 0 {
+		totals = append(totals, fmt.Sprintf("%d without input", s.Skipped))
+	}
+	fmt.Printf("%s in %s\n", strings.Join(totals, ", "), outputhandler.FormatElapsed(elapsed))
+}
+
+func getStarMark() string {
+	if outputhandler.CanUseEmojis() {
+		return "★"
+	}
+	return "*"
+}
+
+func getRegressedMark() string {
+	if outputhandler.CanUseEmojis() {
+		return "✗"
+	}
+	return "x"
+}
+
+func getStoppedMark() string {
+	if outputhandler.CanUseEmojis() {
+		return "…"
+	}
+	return "?"
+}
+
+func getUnverifiedMark() string {
+	if outputhandler.CanUseEmojis() {
+		return "·"
+	}
+	return "."
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"AoC22/internal/runner"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// runDiff solves random inputs of the day with both the real solution and the naive
+// reference solver, and stops at the first input they disagree on. That input is made
+// as small as possible and saved, with the commands to reproduce it.
+func runDiff(args []string) int {
+
+	flags := newFlagSet("diff")
+	runs := flags.Int("runs", 50, "number of random inputs to check")
+	maxSize := flags.Int("size", 20, "the biggest input size to generate, the sizes are random up to it")
+	seed := flags.Int64("seed", 0, "seed of the random inputs, the same seed checks the same inputs (default random)")
+	dayTimeout := flags.Duration("timeout", 10*time.Second, "stop each run after the `duration`, a stopped run is not compared")
+	outPath := flags.String("o", "", "save the reproducer to the `file` (default like day15.diff.txt)")
+	if err := flags.Parse(args); err != nil {
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	if flags.NArg() < 1 {
+		fmt.Println("Error: the day to check is missing")
+		flags.Usage()
+		return int(inputhandler.ErrorCodeParameters)
+	}
+	days, err := calendar.ParseDays(flags.Args()[:1])
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeParameters)
+	}
+	day := days[0]
+	dayArgs := append(append([]string{}, day.DiffArgs...), flags.Args()[1:]...) // passed to both, like -part 2
+
+	r, err := runner.NewRunner()
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeFiles)
+	}
+	defer r.Close()
+
+	if *dayTimeout > 0 {
+		r.Args = append(r.Args, "-timeout", dayTimeout.String())
+	}
+
+	if err := buildDays(r, "Building "+day.ID(), []calendar.Day{day}); err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeProcessing)
+	}
+
+	ctx := inputhandler.GetContext()
+	checker := &diffChecker{r: r, day: day, dayArgs: dayArgs, inputPath: filepath.Join(r.BinDir, "diff-input.txt")}
+
+	// the puzzle data is in the solution, there is nothing to generate
+	if day.NoInput {
+		result := checker.CheckBuiltIn(ctx)
+		if result.HasNoReference() {
+			fmt.Printf("Error: %s has no reference solver to compare to\n", day.ID())
+			return int(inputhandler.ErrorCodeParameters)
+		}
+		printDiffResult(result)
+		switch {
+		case result.Differs():
+			return int(inputhandler.ErrorCodeProcessing)
+		case result.Stopped():
+			return int(inputhandler.ErrorCodeStopped)
+		}
+		return 0
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed))
+	if *maxSize < 1 {
+		*maxSize = 1
+	}
+
+	var agreed, stopped int
+	progress := outputhandler.NewProgressBar("Comparing", int64(*runs))
+	for runIdx := 0; runIdx < *runs && ctx.Err() == nil; runIdx++ {
+
+		input, err := checker.Generate(ctx, rng.Int63(), 1+rng.Intn(*maxSize))
+		if err != nil {
+			progress.Finish()
+			outputhandler.PrintError("Error", err)
+			return int(inputhandler.ErrorCodeProcessing)
+		}
+
+		result := checker.Check(ctx, input)
+		progress.Increment()
+
+		switch {
+		case result.HasNoReference():
+			progress.Finish()
+			fmt.Printf("Error: %s has no reference solver to compare to\n", day.ID())
+			return int(inputhandler.ErrorCodeParameters)
+		case result.Stopped():
+			stopped++
+			continue
+		case !result.Differs():
+			agreed++
+			continue
+		}
+
+		progress.Finish()
+		fmt.Printf("The solutions disagree on the input of seed %d and size %d (%d lines):\n", input.Seed, input.Size, len(input.Lines))
+		printDiffResult(result)
+
+		fmt.Println("Minimizing the input...")
+		input, result = checker.Minimize(ctx, rng, input, result)
+		printDiffResult(result)
+
+		path := *outPath
+		if len(path) == 0 {
+			path = day.Name() + ".diff.txt"
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(input.Lines, "\n")+"\n"), 0644); err != nil {
+			outputhandler.PrintError("Error", fmt.Errorf("couldn't write '%s': %w", path, err))
+			return int(inputhandler.ErrorCodeFiles)
+		}
+
+		command := strings.Join(append([]string{"go run ./" + day.Dir(), "-f", path}, append(input.Params, dayArgs...)...), " ")
+		fmt.Printf("Saved the %d lines to '%s', reproduce with:\n", len(input.Lines), path)
+		fmt.Printf("  %s\n  %s -reference\n", command, command)
+
+		return int(inputhandler.ErrorCodeProcessing)
+	}
+	progress.Finish()
+
+	fmt.Printf("%d inputs agreed", agreed)
+	if stopped > 0 {
+		fmt.Printf(", %d stopped after %s and not compared", stopped, dayTimeout.String())
+	}
+	fmt.Printf(" (seed %d)\n", *seed)
+
+	return 0
+}
+
+//-Checking--------------------------------------------------------------------
+
+// diffInput is a generated input, maybe minimized
+type diffInput struct {
+	Lines  []string
+	Params []string // like "-param", "row=10"
+	Seed   int64
+	Size   int
+}
+
+// diffResult is the outcome of solving an input both ways
+type diffResult struct {
+	Real      runner.DayRun
+	Reference runner.DayRun
+}
+
+// Stopped tells if either of the runs was stopped, there is nothing to compare then.
+func (d diffResult) Stopped() bool {
+	return d.Real.Stopped || d.Reference.Stopped
+}
+
+// HasNoReference tells if the reference run failed because the day has no reference solver.
+func (d diffResult) HasNoReference() bool {
+	return d.Reference.Err != nil && strings.Contains(d.Reference.Err.Error(), inputhandler.ErrorNoReference.Error())
+}
+
+// Differs tells if only one of the runs failed, or their answers are different.
+// A part that only one of them solved is not compared.
+func (d diffResult) Differs() bool {
+
+	if d.Stopped() {
+		return false
+	}
+	if (d.Real.Err != nil) != (d.Reference.Err != nil) {
+		return true
+	}
+	if d.Real.Err != nil || d.Real.Record == nil || d.Reference.Record == nil {
+		return false // the input is invalid for both
+	}
+
+	for _, part := range d.Real.Record.Results {
+		for _, referencePart := range d.Reference.Record.Results {
+			if part.Part == referencePart.Part && part.AnswerText() != referencePart.AnswerText() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// diffChecker runs the day's built binary both ways
+type diffChecker struct {
+	r         *runner.Runner
+	day       calendar.Day
+	dayArgs   []string
+	inputPath string // where the input to check is written
+}
+
+// Generate creates a random input with the day's generator.
+func (c *diffChecker) Generate(ctx context.Context, seed int64, size int) (diffInput, error) {
+
+	generated, err := c.r.Generate(ctx, c.day, seed, size)
+	if err != nil {
+		return diffInput{}, err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(generated.Input), "\n"), "\n")
+	return diffInput{Lines: lines, Params: generated.Params, Seed: generated.Seed, Size: size}, nil
+}
+
+// Check solves the input both ways.
+func (c *diffChecker) Check(ctx context.Context, input diffInput) diffResult {
+
+	if err := os.WriteFile(c.inputPath, []byte(strings.Join(input.Lines, "\n")+"\n"), 0644); err != nil {
+		return diffResult{Real: runner.DayRun{Day: c.day, Err: err}, Reference: runner.DayRun{Day: c.day, Err: err}}
+	}
+
+	args := append(append([]string{}, input.Params...), c.dayArgs...)
+	return diffResult{
+		Real:      c.r.RunInput(ctx, c.day, c.inputPath, args...),
+		Reference: c.r.RunInput(ctx, c.day, c.inputPath, append(args, "-reference")...),
+	}
+}
+
+// CheckBuiltIn solves the day's built-in puzzle data both ways.
+func (c *diffChecker) CheckBuiltIn(ctx context.Context) diffResult {
+	return diffResult{
+		Real:      c.r.Run(ctx, c.day, c.dayArgs...),
+		Reference: c.r.Run(ctx, c.day, append(append([]string{}, c.dayArgs...), "-reference")...),
+	}
+}
+
+// Minimize looks for a smaller input the solutions disagree on, first by generating
+// smaller inputs, then by removing lines (or characters of a single line) for as long
+// as they still disagree.
+func (c *diffChecker) Minimize(ctx context.Context, rng *rand.Rand, input diffInput, result diffResult) (diffInput, diffResult) {
+
+	const triesPerSize = 5
+
+smallerSizes:
+	for size := 1; size < input.Size; size *= 2 {
+		for try := 0; try < triesPerSize && ctx.Err() == nil; try++ {
+			smaller, err := c.Generate(ctx, rng.Int63(), size)
+			if err != nil || len(smaller.Lines) >= len(input.Lines) {
+				continue
+			}
+			if smallerResult := c.Check(ctx, smaller); smallerResult.Differs() {
+				input, result = smaller, smallerResult
+				break smallerSizes
+			}
+		}
+	}
+
+	// tries to remove the units in chunks, halving the chunks until single units
+	removeUnits := func(units []string, join func([]string) []string) []string {
+		for chunk := len(units) / 2; chunk >= 1 && ctx.Err() == nil; chunk /= 2 {
+			for start := 0; start+chunk <= len(units) && len(units) > 1; {
+				candidate := append(append([]string{}, units[:start]...), units[start+chunk:]...)
+				smaller := input
+				smaller.Lines = join(candidate)
+				if smallerResult := c.Check(ctx, smaller); smallerResult.Differs() {
+					units, input, result = candidate, smaller, smallerResult
+					continue // the next chunk is at the same start now
+				}
+				start += chunk
+			}
+		}
+		return units
+	}
+
+	removeUnits(input.Lines, func(lines []string) []string { return lines })
+	if len(input.Lines) == 1 && len(input.Lines[0]) > 1 {
+		removeUnits(strings.Split(input.Lines[0], ""), func(chars []string) []string {
+			return []string{strings.Join(chars, "")}
+		})
+	}
+
+	return input, result
+}
+
+// printDiffResult prints the answers of the runs side by side
+func printDiffResult(result diffResult) {
+
+	table := outputhandler.NewTable("",
+		outputhandler.TableColumn{Header: "Part"},
+		outputhandler.TableColumn{Header: "Solution", Align: outputhandler.AlignRight, Color: outputhandler.BrightGreen},
+		outputhandler.TableColumn{Header: "Reference", Align: outputhandler.AlignRight, Color: outputhandler.BrightCyan},
+		outputhandler.TableColumn{Header: "", Color: outputhandler.BrightRed},
+	)
+
+	answers := func(run runner.DayRun) map[string]string {
+		partAnswers := make(map[string]string)
+		if run.Record != nil {
+			for _, part := range run.Record.Results {
+				partAnswers[part.Part] = formatPartAnswer(part)
+			}
+		}
+		return partAnswers
+	}
+	realAnswers, referenceAnswers := answers(result.Real), answers(result.Reference)
+
+	for _, part := range []string{"Part1", "Part2"} {
+		realAnswer, realOk := realAnswers[part]
+		referenceAnswer, referenceOk := referenceAnswers[part]
+		if !realOk && !referenceOk {
+			continue
+		}
+		mark := ""
+		if realOk && referenceOk && realAnswer != referenceAnswer {
+			mark = "differs"
+		}
+		table.AddRow(part, realAnswer, referenceAnswer, mark)
+	}
+	table.Print()
+
+	if result.Real.Err != nil {
+		outputhandler.PrintError("Solution", result.Real.Err)
+	}
+	if result.Reference.Err != nil {
+		outputhandler.PrintError("Reference", result.Reference.Err)
+	}
+	if result.Stopped() {
+		fmt.Println("Stopped before the answers could be compared")
+	} else if !result.Differs() {
+		fmt.Println("The answers agree")
+	}
+}
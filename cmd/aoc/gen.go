@@ -0,0 +1,78 @@
+package main
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"AoC22/internal/runner"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// runGen prints a random input of the day, or saves it with -o. The seed and the
+// flags to solve it with are printed to stderr, so the input can be piped.
+func runGen(args []string) int {
+
+	flags := newFlagSet("gen")
+	seed := flags.Int64("seed", 0, "the same seed gives the same input (default random)")
+	size := flags.Int("size", 0, "size of the input, like the number of lines (default is about a real input's)")
+	outPath := flags.String("o", "", "save the input to the `file` instead of printing it")
+	if err := flags.Parse(args); err != nil {
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	if flags.NArg() != 1 {
+		fmt.Println("Error: give one day to generate an input for")
+		flags.Usage()
+		return int(inputhandler.ErrorCodeParameters)
+	}
+	days, err := calendar.ParseDays(flags.Args())
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeParameters)
+	}
+	day := days[0]
+	if day.NoInput {
+		fmt.Printf("Error: %s has no input to generate\n", day.ID())
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	// stdout only gets the input, so it can be piped, the rest goes to stderr
+	inputOutput := os.Stdout
+	os.Stdout = os.Stderr
+
+	r, err := runner.NewRunner()
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeFiles)
+	}
+	defer r.Close()
+
+	if err := r.Build([]calendar.Day{day}); err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeProcessing)
+	}
+
+	generated, err := r.Generate(inputhandler.GetContext(), day, *seed, *size)
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeProcessing)
+	}
+
+	if len(*outPath) > 0 {
+		if err := os.WriteFile(*outPath, generated.Input, 0644); err != nil {
+			outputhandler.PrintError("Error", fmt.Errorf("couldn't write '%s': %w", *outPath, err))
+			return int(inputhandler.ErrorCodeFiles)
+		}
+	} else {
+		_, _ = inputOutput.Write(generated.Input)
+	}
+
+	fmt.Printf("seed: %d\n", generated.Seed)
+	if len(generated.Params) > 0 {
+		fmt.Printf("solve with: %s\n", strings.Join(generated.Params, " "))
+	}
+
+	return 0
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"AoC22/internal/runner"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the site asks not to download a leaderboard more often than every 15 minutes,
+// a download is used until then
+const leaderboardCacheAge = 15 * time.Minute
+
+// runLeaderboard shows a private leaderboard with the local scores computed from the
+// star timestamps, or when the members got the stars of a day with -day.
+func runLeaderboard(args []string) int {
+
+	flags := newFlagSet("leaderboard")
+	year := flags.Int("year", calendar.GetLatestYear(), "the year of the leaderboard")
+	id := flags.Int("id", 0, "the `ID` of the leaderboard, the number at the end of its URL")
+	filePath := flags.String("f", "", "load the leaderboard from a saved JSON `file` instead of the site")
+	day := flags.Int("day", 0, "show when the members got the stars of the `day`")
+	if err := flags.Parse(args); err != nil {
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	if *day < 0 || *day > 25 {
+		fmt.Printf("Error: invalid day '%d', the days are 1-25\n", *day)
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	var data []byte
+	var cachePath string // where the download is saved, empty if it's not downloaded
+	switch {
+	case len(*filePath) > 0:
+		var err error
+		if data, err = os.ReadFile(*filePath); err != nil {
+			outputhandler.PrintError("Error", fmt.Errorf("couldn't read file '%s': %w", *filePath, err))
+			return int(inputhandler.ErrorCodeFiles)
+		}
+
+	case *id > 0:
+		root, err := runner.FindModuleRoot()
+		if err != nil {
+			outputhandler.PrintError("Error", err)
+			return int(inputhandler.ErrorCodeFiles)
+		}
+		path := filepath.Join(root, inputhandler.InputStoreDir, strconv.Itoa(*year), fmt.Sprintf("leaderboard_%d.json", *id))
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < leaderboardCacheAge {
+			if data, err = os.ReadFile(path); err == nil {
+				fmt.Printf("Downloaded %s ago, the next download can be in %s\n",
+					time.Since(info.ModTime()).Round(time.Second), (leaderboardCacheAge - time.Since(info.ModTime())).Round(time.Second))
+			}
+		}
+		if data == nil {
+			text, err := inputhandler.GetDataFromWebpage(inputhandler.GetLeaderboardURL(*year, *id))
+			if err != nil {
+				outputhandler.PrintError("Error", fmt.Errorf("couldn't download the leaderboard: %w", err))
+				return int(inputhandler.ErrorCodeNetwork)
+			}
+			data, cachePath = []byte(text), path
+		}
+
+	default:
+		fmt.Println("Error: give the ID of the leaderboard with -id, or a saved one with -f")
+		flags.Usage()
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	board, err := parseLeaderboard(data)
+	if err != nil {
+		if len(cachePath) > 0 {
+			// the site answers with its login page without a valid session
+			err = fmt.Errorf("%w (is the cookie in session.txt still valid?)", err)
+		}
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeData)
+	}
+
+	if len(cachePath) > 0 {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+			err = os.WriteFile(cachePath, data, 0644)
+		}
+		if err != nil {
+			outputhandler.PrintError("Warning", fmt.Errorf("couldn't save the leaderboard to '%s': %w", cachePath, err))
+		}
+	}
+
+	if *day > 0 {
+		printLeaderboardDay(board, *day)
+	} else {
+		printLeaderboard(board)
+	}
+
+	return 0
+}
+
+//-Leaderboard-----------------------------------------------------------------
+
+// leaderboard is a private leaderboard, the members ranked by their local score
+type leaderboard struct {
+	Year    int
+	Members []*leaderboardMember
+}
+
+// leaderboardMember is a member of the leaderboard with the stars by day and part
+type leaderboardMember struct {
+	ID          int64
+	Name        string
+	GlobalScore int
+	LocalScore  int     // computed from the stars, see computeLocalScores()
+	DayPoints   [25]int // the local score by day
+	Stars       [25][2]leaderboardStar
+}
+
+// leaderboardStar is when a member got a star, the zero time if not yet
+type leaderboardStar struct {
+	Time  time.Time
+	Index int64 // the order of the stars on the site, for the ones in the same second
+}
+
+func (s leaderboardStar) IsGot() bool {
+	return !s.Time.IsZero()
+}
+
+// StarCount returns the number of the member's stars.
+func (m *leaderboardMember) StarCount() int {
+
+	var count int
+	for _, parts := range m.Stars {
+		for _, star := range parts {
+			if star.IsGot() {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// LastStar returns the member's latest star, the zero time if there are none.
+func (m *leaderboardMember) LastStar() time.Time {
+
+	var last time.Time
+	for _, parts := range m.Stars {
+		for _, star := range parts {
+			if star.Time.After(last) {
+				last = star.Time
+			}
+		}
+	}
+
+	return last
+}
+
+// GetDelta returns how long part 2 of the day took after part 1, false if not both are got.
+func (m *leaderboardMember) GetDelta(dayIdx int) (time.Duration, bool) {
+
+	part1, part2 := m.Stars[dayIdx][0], m.Stars[dayIdx][1]
+	if !part1.IsGot() || !part2.IsGot() {
+		return 0, false
+	}
+
+	return part2.Time.Sub(part1.Time), true
+}
+
+// getUnlockTime returns when the puzzle of the day was released, midnight EST
+func getUnlockTime(year, day int) time.Time {
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
+
+//-Parsing---------------------------------------------------------------------
+
+// leaderboardNumber is a number in the leaderboard's JSON, the older years have them as strings
+type leaderboardNumber int64
+
+func (n *leaderboardNumber) UnmarshalJSON(data []byte) error {
+
+	text := strings.Trim(string(data), `"`)
+	if text == "null" || len(text) == 0 {
+		*n = 0
+		return nil
+	}
+
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number '%s'", text)
+	}
+	*n = leaderboardNumber(value)
+
+	return nil
+}
+
+// leaderboardJSON is the leaderboard as the site sends it
+type leaderboardJSON struct {
+	Event   string `json:"event"`
+	Members map[string]struct {
+		ID                 leaderboardNumber `json:"id"`
+		Name               string            `json:"name"` // null for the anonymous members
+		GlobalScore        leaderboardNumber `json:"global_score"`
+		CompletionDayLevel map[string]map[string]struct {
+			GetStarTs leaderboardNumber `json:"get_star_ts"`
+			StarIndex leaderboardNumber `json:"star_index"`
+		} `json:"completion_day_level"` // by day and part
+	} `json:"members"`
+}
+
+// parseLeaderboard reads the leaderboard from the site's JSON and ranks the members.
+func parseLeaderboard(data []byte) (*leaderboard, error) {
+
+	var boardJSON leaderboardJSON
+	if err := json.Unmarshal(data, &boardJSON); err != nil {
+		return nil, fmt.Errorf("couldn't parse the leaderboard: %w", err)
+	}
+	if boardJSON.Members == nil {
+		return nil, fmt.Errorf("couldn't parse the leaderboard: no members")
+	}
+
+	year, err := strconv.Atoi(boardJSON.Event)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse the leaderboard: invalid event '%s'", boardJSON.Event)
+	}
+	board := &leaderboard{Year: year, Members: make([]*leaderboardMember, 0, len(boardJSON.Members))}
+
+	for _, memberJSON := range boardJSON.Members {
+
+		member := &leaderboardMember{ID: int64(memberJSON.ID), Name: memberJSON.Name, GlobalScore: int(memberJSON.GlobalScore)}
+		if len(member.Name) == 0 {
+			member.Name = fmt.Sprintf("(anonymous user #%d)", member.ID)
+		}
+
+		for dayText, parts := range memberJSON.CompletionDayLevel {
+			day, err := strconv.Atoi(dayText)
+			if err != nil || day < 1 || day > 25 {
+				return nil, fmt.Errorf("couldn't parse the leaderboard: invalid day '%s' of '%s'", dayText, member.Name)
+			}
+			for partText, star := range parts {
+				if partText != "1" && partText != "2" {
+					return nil, fmt.Errorf("couldn't parse the leaderboard: invalid part '%s' of day %d of '%s'", partText, day, member.Name)
+				}
+				partIdx := int(partText[0] - '1')
+				member.Stars[day-1][partIdx] = leaderboardStar{Time: time.Unix(int64(star.GetStarTs), 0), Index: int64(star.StarIndex)}
+			}
+		}
+
+		board.Members = append(board.Members, member)
+	}
+
+	computeLocalScores(board.Members)
+
+	sort.Slice(board.Members, func(i, j int) bool {
+		member1, member2 := board.Members[i], board.Members[j]
+		switch {
+		case member1.LocalScore != member2.LocalScore:
+			return member1.LocalScore > member2.LocalScore
+		case member1.StarCount() != member2.StarCount():
+			return member1.StarCount() > member2.StarCount()
+		case !member1.LastStar().Equal(member2.LastStar()):
+			return member1.LastStar().Before(member2.LastStar())
+		}
+		return member1.ID < member2.ID
+	})
+
+	return board, nil
+}
+
+// computeLocalScores scores the stars like the site does: the first member to get a star
+// gets as many points as there are members, the second one point less, and so on.
+func computeLocalScores(members []*leaderboardMember) {
+
+	for dayIdx := 0; dayIdx < 25; dayIdx++ {
+		for partIdx := 0; partIdx < 2; partIdx++ {
+
+			got := make([]*leaderboardMember, 0, len(members))
+			for _, member := range members {
+				if member.Stars[dayIdx][partIdx].IsGot() {
+					got = append(got, member)
+				}
+			}
+
+			sort.Slice(got, func(i, j int) bool {
+				star1, star2 := got[i].Stars[dayIdx][partIdx], got[j].Stars[dayIdx][partIdx]
+				if !star1.Time.Equal(star2.Time) {
+					return star1.Time.Before(star2.Time)
+				}
+				return star1.Index < star2.Index
+			})
+
+			for rank, member := range got {
+				member.LocalScore += len(members) - rank
+				member.DayPoints[dayIdx] += len(members) - rank
+			}
+		}
+	}
+}
+
+//-Printing--------------------------------------------------------------------
+
+// printLeaderboard prints the ranking with the stars of every day
+func printLeaderboard(board *leaderboard) {
+
+	// the days are shown until the last one anyone has a star for
+	lastDayIdx := 0
+	for _, member := range board.Members {
+		for dayIdx := range member.Stars {
+			if member.Stars[dayIdx][0].IsGot() && dayIdx > lastDayIdx {
+				lastDayIdx = dayIdx
+			}
+		}
+	}
+
+	table := outputhandler.NewTable(fmt.Sprintf("Private leaderboard %d", board.Year),
+		outputhandler.TableColumn{Header: "#", Align: outputhandler.AlignRight},
+		outputhandler.TableColumn{Header: "Name"},
+		outputhandler.TableColumn{Header: "Local", Align: outputhandler.AlignRight, Color: outputhandler.BrightGreen},
+		outputhandler.TableColumn{Header: "Global", Align: outputhandler.AlignRight, Color: outputhandler.BrightCyan},
+		outputhandler.TableColumn{Header: "Stars", Align: outputhandler.AlignRight, Color: outputhandler.BrightYellow},
+		outputhandler.TableColumn{Header: "Days", Color: outputhandler.BrightYellow},
+		outputhandler.TableColumn{Header: "Avg part 2", Align: outputhandler.AlignRight, Color: outputhandler.Gray},
+		outputhandler.TableColumn{Header: "Last star", Color: outputhandler.Gray},
+	)
+
+	for rank, member := range board.Members {
+
+		var days strings.Builder
+		var deltaSum time.Duration
+		var deltaCount int
+		for dayIdx := 0; dayIdx <= lastDayIdx; dayIdx++ {
+			if dayIdx > 0 && dayIdx%5 == 0 {
+				days.WriteString(" ")
+			}
+			days.WriteString(getDayStarsMark(member.Stars[dayIdx]))
+
+			if delta, ok := member.GetDelta(dayIdx); ok {
+				deltaSum += delta
+				deltaCount++
+			}
+		}
+
+		averageDelta := ""
+		if deltaCount > 0 {
+			averageDelta = formatStarTime(deltaSum / time.Duration(deltaCount))
+		}
+		lastStar := ""
+		if last := member.LastStar(); !last.IsZero() {
+			lastStar = last.Local().Format("Jan 02 15:04")
+		}
+
+		table.AddRow(rank+1, member.Name, member.LocalScore, member.GlobalScore, member.StarCount(), days.String(), averageDelta, lastStar)
+	}
+
+	table.Print()
+	fmt.Printf("%d members, \"Avg part 2\" is how long part 2 took after part 1 on average\n", len(board.Members))
+}
+
+// printLeaderboardDay prints when the members got the stars of the day, from the unlock
+func printLeaderboardDay(board *leaderboard, day int) {
+
+	dayIdx := day - 1
+	members := make([]*leaderboardMember, 0, len(board.Members))
+	for _, member := range board.Members {
+		if member.Stars[dayIdx][0].IsGot() {
+			members = append(members, member)
+		}
+	}
+	if len(members) == 0 {
+		fmt.Printf("No stars on day %d yet\n", day)
+		return
+	}
+
+	// the ones with both stars first, by the time of the last one they got
+	sort.SliceStable(members, func(i, j int) bool {
+		stars1, stars2 := members[i].Stars[dayIdx], members[j].Stars[dayIdx]
+		if stars1[1].IsGot() != stars2[1].IsGot() {
+			return stars1[1].IsGot()
+		}
+		if stars1[1].IsGot() {
+			return stars1[1].Time.Before(stars2[1].Time)
+		}
+		return stars1[0].Time.Before(stars2[0].Time)
+	})
+
+	unlock := getUnlockTime(board.Year, day)
+	table := outputhandler.NewTable(fmt.Sprintf("Private leaderboard %d, day %d", board.Year, day),
+		outputhandler.TableColumn{Header: "#", Align: outputhandler.AlignRight},
+		outputhandler.TableColumn{Header: "Name"},
+		outputhandler.TableColumn{Header: "Part 1", Align: outputhandler.AlignRight, Color: outputhandler.Gray},
+		outputhandler.TableColumn{Header: "Part 2", Align: outputhandler.AlignRight, Color: outputhandler.BrightYellow},
+		outputhandler.TableColumn{Header: "Part 1 to 2", Align: outputhandler.AlignRight, Color: outputhandler.BrightCyan},
+		outputhandler.TableColumn{Header: "Points", Align: outputhandler.AlignRight, Color: outputhandler.BrightGreen},
+	)
+
+	for rank, member := range members {
+		stars := member.Stars[dayIdx]
+		part2, partDelta := "", ""
+		if stars[1].IsGot() {
+			part2 = formatStarTime(stars[1].Time.Sub(unlock))
+			delta, _ := member.GetDelta(dayIdx)
+			partDelta = formatStarTime(delta)
+		}
+		table.AddRow(rank+1, member.Name, formatStarTime(stars[0].Time.Sub(unlock)), part2, partDelta, member.DayPoints[dayIdx])
+	}
+
+	table.Print()
+	fmt.Printf("The times are from the unlock at %s\n", unlock.Local().Format("Jan 02 15:04 MST"))
+}
+
+// formatStarTime formats the time like the site does, like 01:02:03. The hours go over 24.
+func formatStarTime(elapsed time.Duration) string {
+
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	seconds := int64(elapsed.Round(time.Second) / time.Second)
+
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+// getDayStarsMark returns the mark of the day's stars: both, only the first or none
+func getDayStarsMark(stars [2]leaderboardStar) string {
+
+	full, half, none := "*", "+", "."
+	if outputhandler.CanUseEmojis() {
+		full, half, none = "★", "☆", "·"
+	}
+
+	switch {
+	case stars[1].IsGot():
+		return full
+	case stars[0].IsGot():
+		return half
+	}
+	return none
+}
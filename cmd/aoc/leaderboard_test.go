@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loadTestLeaderboard parses the saved leaderboard, with the numbers of the members 1 and 3
+// as numbers and the ones of member 2 as strings like in the older years. Members 1 and 2
+// got the first star of day 1 in the same second, member 2 first by the star index.
+func loadTestLeaderboard(t *testing.T) *leaderboard {
+
+	data, err := os.ReadFile(filepath.Join("testdata", "leaderboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	board, err := parseLeaderboard(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return board
+}
+
+func TestParseLeaderboard(t *testing.T) {
+
+	board := loadTestLeaderboard(t)
+	if board.Year != 2022 {
+		t.Errorf("got year %d, want 2022", board.Year)
+	}
+
+	want := []struct {
+		name        string
+		localScore  int
+		globalScore int
+		stars       int
+		dayPoints   [2]int // of days 1 and 2
+	}{
+		{"Alice", 8, 0, 3, [2]int{2 + 3, 3}},
+		{"(anonymous user #2)", 5, 5, 2, [2]int{3 + 2, 0}},
+		{"Carol", 0, 0, 0, [2]int{0, 0}},
+	}
+
+	if len(board.Members) != len(want) {
+		t.Fatalf("got %d members, want %d", len(board.Members), len(want))
+	}
+	for rank, member := range board.Members {
+		wantMember := want[rank]
+		if member.Name != wantMember.name {
+			t.Errorf("rank %d: got '%s', want '%s'", rank+1, member.Name, wantMember.name)
+			continue
+		}
+		if member.LocalScore != wantMember.localScore || member.GlobalScore != wantMember.globalScore || member.StarCount() != wantMember.stars {
+			t.Errorf("%s: got local score %d, global score %d and %d stars, want %d, %d and %d", member.Name,
+				member.LocalScore, member.GlobalScore, member.StarCount(), wantMember.localScore, wantMember.globalScore, wantMember.stars)
+		}
+		if member.DayPoints[0] != wantMember.dayPoints[0] || member.DayPoints[1] != wantMember.dayPoints[1] {
+			t.Errorf("%s: got day points %v, want %v", member.Name, member.DayPoints[:2], wantMember.dayPoints)
+		}
+	}
+
+	alice := board.Members[0]
+	if delta, ok := alice.GetDelta(0); !ok || delta != time.Minute {
+		t.Errorf("got part 2 of day 1 after %v (%t), want 1m0s", delta, ok)
+	}
+	if _, ok := alice.GetDelta(1); ok {
+		t.Errorf("got a part 2 time for day 2 with only part 1")
+	}
+	if last := alice.LastStar(); !last.Equal(time.Unix(1669957200, 0)) {
+		t.Errorf("got the last star at %v, want %v", last, time.Unix(1669957200, 0))
+	}
+}
+
+func TestLeaderboardRankingTies(t *testing.T) {
+
+	// 5 and 7 both get one star nobody else has, 5 got it first. 8 and 9 have none.
+	data := `{"event": "2022", "members": {
+		"9": {"id": 9, "name": "Ivan"},
+		"7": {"id": 7, "name": "Grace", "completion_day_level": {"2": {"1": {"get_star_ts": 1669957300, "star_index": 2}}}},
+		"8": {"id": 8, "name": "Heidi"},
+		"5": {"id": 5, "name": "Erin", "completion_day_level": {"1": {"1": {"get_star_ts": 1669870900, "star_index": 1}}}}
+	}}`
+
+	board, err := parseLeaderboard([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Erin", "Grace", "Heidi", "Ivan"}
+	for rank, member := range board.Members {
+		if member.Name != want[rank] {
+			t.Errorf("rank %d: got '%s', want '%s'", rank+1, member.Name, want[rank])
+		}
+	}
+	if board.Members[0].LocalScore != board.Members[1].LocalScore {
+		t.Errorf("got local scores %d and %d, want a tie", board.Members[0].LocalScore, board.Members[1].LocalScore)
+	}
+}
+
+func TestParseLeaderboardInvalid(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"not JSON", "<html>", "couldn't parse the leaderboard"},
+		{"no members", `{"event": "2022"}`, "no members"},
+		{"invalid event", `{"event": "next", "members": {}}`, "invalid event 'next'"},
+		{"invalid number", `{"event": "2022", "members": {"1": {"id": "one"}}}`, "invalid number 'one'"},
+		{"invalid day", `{"event": "2022", "members": {"1": {"id": 1, "completion_day_level": {"26": {}}}}}`, "invalid day '26'"},
+		{"invalid part", `{"event": "2022", "members": {"1": {"id": 1, "completion_day_level": {"1": {"3": {}}}}}}`, "invalid part '3'"},
+	}
+
+	for _, test := range tests {
+		_, err := parseLeaderboard([]byte(test.data))
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("%s: got error %v, want one with '%s'", test.name, err, test.wantErr)
+		}
+	}
+}
+
+func TestLeaderboardNumber(t *testing.T) {
+
+	tests := []struct {
+		json    string
+		want    leaderboardNumber
+		wantErr bool
+	}{
+		{`1669870860`, 1669870860, false},
+		{`"1669870860"`, 1669870860, false},
+		{`null`, 0, false},
+		{`""`, 0, false},
+		{`"12a"`, 0, true},
+		{`1.5`, 0, true},
+	}
+
+	for _, test := range tests {
+		var number leaderboardNumber
+		err := json.Unmarshal([]byte(test.json), &number)
+		if (err != nil) != test.wantErr || number != test.want {
+			t.Errorf("%s: got %d, %v, want %d with error %t", test.json, number, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestComputeLocalScores(t *testing.T) {
+
+	at := func(seconds int64, index int64) leaderboardStar {
+		return leaderboardStar{Time: time.Unix(1669870800+seconds, 0), Index: index}
+	}
+
+	// the members in the order they are given, the site's order doesn't matter
+	members := []*leaderboardMember{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	members[0].Stars[0] = [2]leaderboardStar{at(100, 3), at(200, 8)}
+	members[1].Stars[0] = [2]leaderboardStar{at(100, 2), at(150, 6)} // the same second as member 1, but before it
+	members[2].Stars[0] = [2]leaderboardStar{at(50, 1), {}}
+	members[3].Stars[4] = [2]leaderboardStar{at(90000, 20), {}}
+
+	computeLocalScores(members)
+
+	want := []int{2 + 3, 3 + 4, 4, 4}
+	for memberIdx, member := range members {
+		if member.LocalScore != want[memberIdx] {
+			t.Errorf("member %d: got %d points, want %d", member.ID, member.LocalScore, want[memberIdx])
+		}
+	}
+	if members[3].DayPoints[4] != 4 || members[3].DayPoints[0] != 0 {
+		t.Errorf("member 4: got day points %v, want 4 on day 5 only", members[3].DayPoints)
+	}
+}
+
+func TestFormatStarTime(t *testing.T) {
+
+	tests := []struct {
+		elapsed time.Duration
+		want    string
+	}{
+		{0, "00:00:00"},
+		{59600 * time.Millisecond, "00:01:00"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "01:02:03"},
+		{26 * time.Hour, "26:00:00"},
+		{-time.Minute, "00:00:00"}, // a star before the unlock, the clocks differ
+	}
+
+	for _, test := range tests {
+		if got := formatStarTime(test.elapsed); got != test.want {
+			t.Errorf("%v: got '%s', want '%s'", test.elapsed, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"AoC22/internal/runner"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// command is a subcommand of the tool, like "all"
+type command struct {
+	Name        string
+	Usage       string
+	Description string
+	Run         func(args []string) int // returns the exit code
+}
+
+var commands = []command{
+	{
+		Name:        "all",
+		Usage:       "all [-workers n] [-timeout duration] [-accept] [-example] [days...]",
+		Description: "builds and runs the days (all of them by default) and prints a summary",
+		Run:         runAll,
+	},
+	{
+		Name:        "watch",
+		Usage:       "watch [-interval duration] [-debounce duration] [-timeout duration] <day> [day flags...]",
+		Description: "re-runs the day when its sources or input change and shows how the answers changed",
+		Run:         runWatch,
+	},
+	{
+		Name:        "gen",
+		Usage:       "gen [-seed n] [-size n] [-o file] <day>",
+		Description: "prints a random input of the day, the same seed gives the same input",
+		Run:         runGen,
+	},
+	{
+		Name:        "diff",
+		Usage:       "diff [-runs n] [-size n] [-seed n] [-timeout duration] [-o file] <day> [day flags...]",
+		Description: "compares the day's solution to its naive reference solver on random inputs",
+		Run:         runDiff,
+	},
+	{
+		Name:        "stats",
+		Usage:       "stats [-trend n] [days...]",
+		Description: "shows from the run history how the days were solved and how their run times changed",
+		Run:         runStats,
+	},
+	{
+		Name:        "leaderboard",
+		Usage:       "leaderboard [-year n] [-id n | -f file] [-day n]",
+		Description: "shows a private leaderboard with the local scores, or the times of the stars of one day",
+		Run:         runLeaderboard,
+	},
+}
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
+	if err := inputhandler.ParseFlags(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		printUsage()
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		printUsage()
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	for _, cmd := range commands {
+		if cmd.Name == args[0] {
+			return cmd.Run(args[1:])
+		}
+	}
+
+	fmt.Printf("Error: unknown command '%s'\n", args[0])
+	printUsage()
+
+	return int(inputhandler.ErrorCodeParameters)
+}
+
+func printUsage() {
+
+	fmt.Println("Usage: aoc [flags] <command> [arguments]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, cmd := range commands {
+		fmt.Printf("  %s\n", cmd.Usage)
+		fmt.Printf("    \t%s\n", cmd.Description)
+	}
+	fmt.Println()
+	fmt.Println("Days are given like '2022/5' or '2022/day05', a whole year like '2022'.")
+	fmt.Printf("Days without a year, like '5' or 'day05', are from %d.\n", calendar.GetLatestYear())
+}
+
+// newFlagSet creates the flag set of a command, the errors are printed
+// with the command's usage.
+func newFlagSet(name string) *flag.FlagSet {
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(os.Stdout)
+
+	return flags
+}
+
+// buildDays builds the days with a spinner, go build gives no progress to show
+func buildDays(r *runner.Runner, title string, days []calendar.Day) error {
+
+	spinner := outputhandler.NewSpinner(title)
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Build(days)
+	}()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				spinner.Finish("failed")
+			} else {
+				spinner.Finish("done")
+			}
+			return err
+		case <-ticker.C:
+			spinner.Tick("go build")
+		}
+	}
+}
+
+// formatPartAnswer returns the answer of the part, with the reason if it was stopped
+func formatPartAnswer(part outputhandler.PartRecord) string {
+	if len(part.Stopped) > 0 {
+		return fmt.Sprintf("%s: %s", part.Stopped, part.AnswerText())
+	}
+	return part.AnswerText()
+}
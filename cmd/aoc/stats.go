@@ -0,0 +1,231 @@
+package main
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"AoC22/internal/runner"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// runStats prints what the run history tells about the days: how long it took to
+// solve them, after how many distinct answers, and how the run times changed.
+// Only the runs of the all and watch commands are in the history.
+func runStats(args []string) int {
+
+	flags := newFlagSet("stats")
+	trendRuns := flags.Int("trend", 12, "the number of latest runs shown in the trend")
+	if err := flags.Parse(args); err != nil {
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	days, err := calendar.ParseDays(flags.Args())
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	root, err := runner.FindModuleRoot()
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeFiles)
+	}
+
+	entries, err := runner.LoadHistory(filepath.Join(root, runner.HistoryPath))
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeFiles)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No runs in the history yet, only the all and watch commands add them to '%s'\n", runner.HistoryPath)
+		return 0
+	}
+
+	statsByDay := make(map[string]*dayStats)
+	for _, day := range days {
+		statsByDay[day.ID()] = &dayStats{Day: day}
+	}
+	for _, entry := range entries {
+		day, ok := calendar.GetDay(entry.Year, entry.Day)
+		if !ok {
+			continue // a day that was removed since
+		}
+		if stats, ok := statsByDay[day.ID()]; ok {
+			stats.Runs = append(stats.Runs, entry)
+		}
+	}
+
+	tables := make(map[int]*outputhandler.Table)
+	years := make([]int, 0)
+	var runCount, dayCount, solvedCount, partCount int
+	for _, day := range days {
+
+		stats := statsByDay[day.ID()]
+		if len(stats.Runs) == 0 {
+			continue
+		}
+		if answers, err := runner.LoadAnswers(filepath.Join(root, day.AnswersPath())); err != nil {
+			outputhandler.PrintError("Warning", err)
+		} else {
+			stats.Answers = answers
+		}
+
+		table, ok := tables[day.Year]
+		if !ok {
+			table = newStatsTable(day.Year)
+			tables[day.Year] = table
+			years = append(years, day.Year)
+		}
+
+		progress := []partProgress{stats.GetProgress("Part1"), stats.GetProgress("Part2")}
+		solved := make([]string, len(progress))
+		distinctAnswers := make([]string, len(progress))
+		for partIdx, part := range progress {
+			solved[partIdx] = "-"
+			if part.Solved {
+				solved[partIdx] = formatSolveTime(part.SolvedAt.Sub(stats.Runs[0].Time))
+				solvedCount++
+			}
+			distinctAnswers[partIdx] = fmt.Sprint(part.DistinctAnswers)
+		}
+
+		fastest, latest, trend := "", "", ""
+		if completeRuns := stats.GetCompleteRuns(); len(completeRuns) > 0 {
+			fastestRun := completeRuns[0]
+			for _, run := range completeRuns {
+				if run.SolveElapsed() < fastestRun.SolveElapsed() {
+					fastestRun = run
+				}
+			}
+			fastest = outputhandler.FormatElapsed(fastestRun.SolveElapsed())
+			latest = outputhandler.FormatElapsed(completeRuns[len(completeRuns)-1].SolveElapsed())
+			trend = formatTrend(completeRuns, *trendRuns)
+		}
+
+		table.AddRow(day.Number, day.Title, len(stats.Runs), stats.Runs[0].Time.Format("2006-01-02 15:04"),
+			strings.Join(solved, " / "), strings.Join(distinctAnswers, " / "), fastest, latest, trend)
+
+		runCount += len(stats.Runs)
+		dayCount++
+		partCount += 2
+	}
+
+	sort.Ints(years)
+	for _, year := range years {
+		tables[year].Print()
+	}
+	fmt.Printf("%d runs of %d days, %d of %d parts solved\n", runCount, dayCount, solvedCount, partCount)
+	fmt.Println("The distinct answers are the ones the recorded runs gave, not the ones sent to the site")
+
+	return 0
+}
+
+func newStatsTable(year int) *outputhandler.Table {
+	return outputhandler.NewTable(fmt.Sprintf("Advent of Code %d", year),
+		outputhandler.TableColumn{Header: "Day", Align: outputhandler.AlignRight},
+		outputhandler.TableColumn{Header: "Title"},
+		outputhandler.TableColumn{Header: "Runs", Align: outputhandler.AlignRight},
+		outputhandler.TableColumn{Header: "First run", Color: outputhandler.Gray},
+		outputhandler.TableColumn{Header: "Solved in", Align: outputhandler.AlignRight, Color: outputhandler.BrightGreen},
+		outputhandler.TableColumn{Header: "Distinct answers", Align: outputhandler.AlignRight, Color: outputhandler.BrightYellow},
+		outputhandler.TableColumn{Header: "Fastest", Align: outputhandler.AlignRight, Color: outputhandler.BrightCyan},
+		outputhandler.TableColumn{Header: "Latest", Align: outputhandler.AlignRight, Color: outputhandler.Gray},
+		outputhandler.TableColumn{Header: "Trend", Color: outputhandler.Gray},
+	)
+}
+
+//-Stats-----------------------------------------------------------------------
+
+// dayStats are the runs of a day from the history
+type dayStats struct {
+	Day     calendar.Day
+	Runs    []runner.HistoryEntry // in the order they ran
+	Answers *runner.Answers       // the accepted ones, nil if there are none
+}
+
+// partProgress is how the solving of a part went. There's no submit command, so the
+// answers sent to the site are not known, only the ones the recorded runs gave.
+type partProgress struct {
+	DistinctAnswers int // given by the runs until the accepted one, or so far
+	Solved          bool
+	SolvedAt        time.Time // the first run with the accepted answer
+}
+
+// GetProgress follows the answers of the part until the accepted one. An answer only
+// counts as accepted for the input it was accepted for, see runner.Answers.
+func (s *dayStats) GetProgress(partName string) partProgress {
+
+	var progress partProgress
+	seen := make(map[string]bool) // by the input's checksum and the answer
+	for _, run := range s.Runs {
+		for _, part := range run.Parts {
+			if part.Part != partName || part.Stopped {
+				continue
+			}
+
+			key := run.Checksum + "\n" + part.Answer
+			if !seen[key] {
+				seen[key] = true
+				progress.DistinctAnswers++
+			}
+
+			if s.Answers != nil && s.Answers.Checksum == run.Checksum && s.Answers.Answers[partName] == part.Answer {
+				progress.Solved = true
+				progress.SolvedAt = run.Time
+				return progress
+			}
+		}
+	}
+
+	return progress
+}
+
+// GetCompleteRuns returns the runs that solved every part they were asked for.
+func (s *dayStats) GetCompleteRuns() []runner.HistoryEntry {
+
+	runs := make([]runner.HistoryEntry, 0, len(s.Runs))
+	for _, run := range s.Runs {
+		if run.IsComplete() {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs
+}
+
+// formatSolveTime rounds the time it took to solve a part to seconds, like 1h2m3s
+func formatSolveTime(elapsed time.Duration) string {
+	if elapsed < time.Second {
+		return "first run"
+	}
+	return elapsed.Round(time.Second).String()
+}
+
+// formatTrend draws the times of the latest runs with the change from the first
+// of them to the last, like "▇▅▃▁ -62%"
+func formatTrend(runs []runner.HistoryEntry, count int) string {
+
+	if count < 2 || len(runs) < 2 {
+		return ""
+	}
+	if len(runs) > count {
+		runs = runs[len(runs)-count:]
+	}
+
+	values := make([]float64, len(runs))
+	for idx, run := range runs {
+		values[idx] = float64(run.SolveElapsed())
+	}
+
+	trend := outputhandler.Sparkline(values)
+	if first := values[0]; first > 0 {
+		trend += fmt.Sprintf(" %+.0f%%", (values[len(values)-1]-first)/first*100)
+	}
+
+	return trend
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"AoC22/internal/runner"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// runWatch rebuilds and re-runs a day whenever its sources, the shared packages
+// or its input change, and shows how the answers changed since the previous run.
+// Runs until Ctrl-C.
+func runWatch(args []string) int {
+
+	flags := newFlagSet("watch")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often the files are checked")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "how long the files have to stay unchanged before a re-run")
+	dayTimeout := flags.Duration("timeout", 0, "stop each run after the `duration`, like 30s (default no limit)")
+	if err := flags.Parse(args); err != nil {
+		return int(inputhandler.ErrorCodeParameters)
+	}
+
+	if flags.NArg() < 1 {
+		fmt.Println("Error: the day to watch is missing")
+		flags.Usage()
+		return int(inputhandler.ErrorCodeParameters)
+	}
+	days, err := calendar.ParseDays(flags.Args()[:1])
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeParameters)
+	}
+	day := days[0]
+	dayArgs := flags.Args()[1:] // passed to the day, like -part 2
+
+	r, err := runner.NewRunner()
+	if err != nil {
+		outputhandler.PrintError("Error", err)
+		return int(inputhandler.ErrorCodeFiles)
+	}
+	defer r.Close()
+	r.HistoryPath, r.Command = filepath.Join(r.Root, runner.HistoryPath), "watch"
+
+	if *dayTimeout > 0 {
+		r.Args = append(r.Args, "-timeout", dayTimeout.String())
+	}
+
+	watcher := runner.NewFileWatcher([]string{
+		filepath.Join(r.Root, day.Dir()),
+		filepath.Join(r.Root, day.InputPath()),
+		filepath.Join(r.Root, "internal"),
+	}, []string{".go"}, *interval, *debounce)
+
+	ctx := inputhandler.GetContext()
+	var previous *outputhandler.RunRecord
+	var changed []string
+	for {
+		outputhandler.ClearScreen()
+		fmt.Printf("Watching %s (%s), press Ctrl-C to stop\n", day.ID(), day.Title)
+		for _, path := range changed {
+			if rel, err := filepath.Rel(r.Root, path); err == nil {
+				path = rel
+			}
+			fmt.Println(outputhandler.GetForeground(outputhandler.Gray) + "changed: " + path + outputhandler.GetReset())
+		}
+		fmt.Println()
+
+		previous = buildAndRun(r, day, dayArgs, previous)
+
+		changed, err = watcher.Wait(ctx)
+		if err != nil {
+			// stopped with Ctrl-C
+			return 0
+		}
+	}
+}
+
+// buildAndRun rebuilds and runs the day, and prints the answers compared to the previous
+// ones. Returns the record to compare the next run to.
+func buildAndRun(r *runner.Runner, day calendar.Day, dayArgs []string, previous *outputhandler.RunRecord) *outputhandler.RunRecord {
+
+	buildStart := time.Now()
+	if err := r.Build([]calendar.Day{day}); err != nil {
+		outputhandler.PrintError("Error", err)
+		return previous
+	}
+	buildElapsed := time.Since(buildStart)
+
+	run := r.Run(inputhandler.GetContext(), day, dayArgs...)
+	if run.Err != nil {
+		outputhandler.PrintError("Error", run.Err)
+	}
+	if run.Record == nil {
+		return previous
+	}
+
+	printAnswerChanges(run.Record, previous)
+	fmt.Printf("Built in %s, ran in %s at %s\n", outputhandler.FormatElapsed(buildElapsed),
+		outputhandler.FormatElapsed(run.Elapsed), time.Now().Format("15:04:05"))
+
+	return run.Record
+}
+
+// printAnswerChanges prints the answers with the previous ones next to them
+func printAnswerChanges(record, previous *outputhandler.RunRecord) {
+
+	previousAnswers := make(map[string]string)
+	if previous != nil {
+		for _, part := range previous.Results {
+			previousAnswers[part.Part] = formatPartAnswer(part)
+		}
+	}
+
+	table := outputhandler.NewTable(fmt.Sprintf("%d Day %02d", record.Year, record.Day),
+		outputhandler.TableColumn{Header: "Part"},
+		outputhandler.TableColumn{Header: record.Title, Align: outputhandler.AlignRight, Color: outputhandler.BrightGreen},
+		outputhandler.TableColumn{Header: "Previous", Align: outputhandler.AlignRight, Color: outputhandler.Gray},
+		outputhandler.TableColumn{Header: "Time", Align: outputhandler.AlignRight, Color: outputhandler.Gray},
+		outputhandler.TableColumn{Header: "Change", Color: outputhandler.BrightYellow},
+	)
+	for _, part := range record.Results {
+
+		answer := formatPartAnswer(part)
+		previousAnswer, ok := previousAnswers[part.Part]
+
+		change := "changed"
+		switch {
+		case !ok:
+			change = "new"
+		case previousAnswer == answer:
+			change = "same"
+		}
+
+		table.AddRow(part.Part, answer, previousAnswer, outputhandler.FormatElapsed(time.Duration(part.ElapsedNs)), change)
+	}
+
+	table.Print()
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"AoC22/internal/inputhandler"
+	_ "embed"
+)
+
+//go:embed example.txt
+var exampleInput string
+
+func init() {
+	inputhandler.SetExample(inputhandler.Example{
+		Input:   exampleInput,
+		Answers: map[string]string{
+			// the answers of the example from the puzzle's description, like:
+			// "Part1": "24000",
+		},
+	})
+}
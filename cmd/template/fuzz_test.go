@@ -0,0 +1,22 @@
+package main
+
+import (
+	"AoC22/internal/parsetest"
+	"testing"
+)
+
+// FuzzSolve checks that any input is solved or rejected, without a panic.
+func FuzzSolve(f *testing.F) {
+
+	f.Add(exampleInput)
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := parsetest.Lines(input)
+
+		_, err := solvePart1(lines)
+		parsetest.CheckError(t, err)
+
+		_, err = solvePart2(lines)
+		parsetest.CheckError(t, err)
+	})
+}
@@ -2,16 +2,62 @@ package main
 
 import (
 	"AoC22/internal/inputhandler"
-	"fmt"
+	"AoC22/internal/outputhandler"
 )
 
+// A new day starts as a copy of this directory in 'cmd/<year>/dayNN'. Set the year,
+// the day and the title in main(), the example in example.txt and example.go, and
+// register the day in 'internal/calendar' so the aoc tool picks it up.
+
 func main() {
 
+	outputhandler.Initialize()
+	defer outputhandler.Reset()
+
 	lines := inputhandler.ReadInput()
+	results := outputhandler.NewResults(2022, 0, "Result")
+
+	if inputhandler.IsPartRequested(1) {
+		resultPart1, err := solvePart1(lines)
+		if err != nil {
+			outputhandler.PrintError("Error", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part1", resultPart1)
+	}
+
+	if inputhandler.IsPartRequested(2) {
+		resultPart2, err := solvePart2(lines)
+		if err != nil {
+			outputhandler.PrintError("Error", err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		}
+
+		results.Add("Part2", resultPart2)
+	}
+
+	results.Print()
+}
+
+func solvePart1(lines []string) (int, error) {
+
+	var result int
+	for lineIdx, line := range lines {
+
+		// do something with the input lines, report the invalid ones with their position
+		if len(line) == 0 {
+			return 0, inputhandler.NewParseError(lineIdx, line, -1, "empty line")
+		}
+	}
+
+	return result, nil
+}
+
+func solvePart2(lines []string) (int, error) {
 
 	var result int
-	// do something with input lines
 	_ = lines
 
-	fmt.Printf("Result: %d", result)
+	return result, nil
 }
@@ -0,0 +1,171 @@
+// Lists the solved days of the calendars, for the tools that work with all of them.
+// Only the days are listed, the solutions stay separate programs that the tools build.
+//
+// Every year registers its days in its own file (see year2022.go), a new day has
+// to be added there to show up in the tools. Days are addressed like "2022/5",
+// a day without a year is from the latest year.
+package calendar
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Day is a solved day of a calendar.
+type Day struct {
+	Year    int
+	Number  int
+	Title   string
+	NoInput bool // the puzzle data is hardcoded in the solution
+
+	DiffArgs []string // passed to both runs of "aoc diff", like fewer rounds for a slow reference solver
+}
+
+// the solved days by year, in order
+var registry = make(map[int][]Day)
+
+// Register adds the solved days of the year.
+func Register(year int, days ...Day) {
+
+	for _, day := range days {
+		day.Year = year
+		registry[year] = append(registry[year], day)
+	}
+
+	sort.Slice(registry[year], func(i, j int) bool {
+		return registry[year][i].Number < registry[year][j].Number
+	})
+}
+
+// GetYears returns the years with solved days in order.
+func GetYears() []int {
+
+	years := make([]int, 0, len(registry))
+	for year := range registry {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+
+	return years
+}
+
+// GetLatestYear returns the latest year with solved days, the default of the addressing.
+func GetLatestYear() int {
+
+	years := GetYears()
+	if len(years) == 0 {
+		return 0
+	}
+
+	return years[len(years)-1]
+}
+
+// GetDays returns the solved days of every year in order.
+func GetDays() []Day {
+
+	days := make([]Day, 0)
+	for _, year := range GetYears() {
+		days = append(days, registry[year]...)
+	}
+
+	return days
+}
+
+// GetYearDays returns the solved days of the year in order.
+func GetYearDays(year int) []Day {
+	return append([]Day{}, registry[year]...)
+}
+
+// GetDay returns the solved day by its year and number.
+func GetDay(year, number int) (Day, bool) {
+	for _, day := range registry[year] {
+		if day.Number == number {
+			return day, true
+		}
+	}
+	return Day{}, false
+}
+
+// ParseDays returns the days listed like "2022/5", "5" or "day05" (from the latest year),
+// or "2022" for a whole year. Returns every day of every year if the list is empty.
+func ParseDays(args []string) ([]Day, error) {
+
+	if len(args) == 0 {
+		return GetDays(), nil
+	}
+
+	selected := make([]Day, 0, len(args))
+	for _, arg := range args {
+
+		yearText, dayText := "", arg
+		if slashIdx := strings.Index(arg, "/"); slashIdx >= 0 {
+			yearText, dayText = arg[:slashIdx], arg[slashIdx+1:]
+		}
+
+		year := GetLatestYear()
+		if len(yearText) > 0 {
+			var err error
+			if year, err = strconv.Atoi(yearText); err != nil {
+				return nil, fmt.Errorf("invalid year '%s' in '%s'", yearText, arg)
+			}
+		}
+
+		number, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(dayText), "day"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid day '%s'", arg)
+		}
+
+		// days only go up to 25, anything bigger alone is a year
+		if len(yearText) == 0 && number > 25 {
+			days := GetYearDays(number)
+			if len(days) == 0 {
+				return nil, fmt.Errorf("year '%d' has no solved days", number)
+			}
+			selected = append(selected, days...)
+			continue
+		}
+
+		day, ok := GetDay(year, number)
+		if !ok {
+			return nil, fmt.Errorf("day '%d/%d' is not solved", year, number)
+		}
+		selected = append(selected, day)
+	}
+
+	return selected, nil
+}
+
+// Name returns the name of the solution, like "day01".
+func (d Day) Name() string {
+	return fmt.Sprintf("day%02d", d.Number)
+}
+
+// ID returns the day with its year, like "2022/day01".
+func (d Day) ID() string {
+	return fmt.Sprintf("%d/%s", d.Year, d.Name())
+}
+
+// Dir returns the directory of the solution relative to the module root, like "cmd/2022/day01".
+func (d Day) Dir() string {
+	return filepath.Join("cmd", strconv.Itoa(d.Year), d.Name())
+}
+
+// Package returns the import path pattern for go build, like "./cmd/2022/day01".
+func (d Day) Package() string {
+	return fmt.Sprintf("./cmd/%d/%s", d.Year, d.Name())
+}
+
+// InputPath returns where the day's personal input is kept, relative to the module root.
+func (d Day) InputPath() string {
+	return inputhandler.GetStoredInputPath(d.Year, d.Number)
+}
+
+// AnswersPath returns where the day's verified answers are kept, relative to the module root.
+// They are kept next to the input, they are only valid for that.
+func (d Day) AnswersPath() string {
+	return strings.TrimSuffix(d.InputPath(), ".txt") + ".answers.json"
+}
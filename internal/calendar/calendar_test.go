@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withTestYear registers a few days of an older year for the test
+func withTestYear(t *testing.T) {
+
+	Register(2015,
+		Day{Number: 2, Title: "I Was Told There Would Be No Math"},
+		Day{Number: 1, Title: "Not Quite Lisp"},
+	)
+	t.Cleanup(func() {
+		delete(registry, 2015)
+	})
+}
+
+func TestParseDays(t *testing.T) {
+
+	withTestYear(t)
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"5"}, []string{"2022/day05"}},
+		{[]string{"day05", "DAY7"}, []string{"2022/day05", "2022/day07"}},
+		{[]string{"2022/21", "2015/1"}, []string{"2022/day21", "2015/day01"}},
+		{[]string{"2015/day02"}, []string{"2015/day02"}},
+		{[]string{"2015"}, []string{"2015/day01", "2015/day02"}}, // in order
+	}
+
+	for _, test := range tests {
+		days, err := ParseDays(test.args)
+		if err != nil {
+			t.Errorf("%v: %v", test.args, err)
+			continue
+		}
+		if got := getDayIDs(days); strings.Join(got, " ") != strings.Join(test.want, " ") {
+			t.Errorf("%v: got %v, want %v", test.args, got, test.want)
+		}
+	}
+}
+
+func TestParseDaysAll(t *testing.T) {
+
+	withTestYear(t)
+
+	days, err := ParseDays(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(days) != len(GetYearDays(2015))+len(GetYearDays(2022)) {
+		t.Errorf("got %d days, want every day of 2015 and 2022", len(days))
+	}
+	if days[0].ID() != "2015/day01" || days[len(days)-1].ID() != "2022/day21" {
+		t.Errorf("got %s to %s, want the years in order", days[0].ID(), days[len(days)-1].ID())
+	}
+}
+
+func TestParseDaysErrors(t *testing.T) {
+
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"x", "invalid day 'x'"},
+		{"day", "invalid day 'day'"},
+		{"x/5", "invalid year 'x' in 'x/5'"},
+		{"2022/x", "invalid day '2022/x'"},
+		{"16", "day '2022/16' is not solved"},
+		{"2022/26", "day '2022/26' is not solved"},
+		{"2014", "year '2014' has no solved days"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseDays([]string{test.arg})
+		if err == nil {
+			t.Errorf("%s: no error, want %q", test.arg, test.want)
+		} else if err.Error() != test.want {
+			t.Errorf("%s: got %q, want %q", test.arg, err, test.want)
+		}
+	}
+}
+
+func TestDayPaths(t *testing.T) {
+
+	day, ok := GetDay(2022, 5)
+	if !ok {
+		t.Fatal("day 2022/5 is not registered")
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Name", day.Name(), "day05"},
+		{"ID", day.ID(), "2022/day05"},
+		{"Dir", day.Dir(), filepath.Join("cmd", "2022", "day05")},
+		{"Package", day.Package(), "./cmd/2022/day05"},
+		{"InputPath", day.InputPath(), filepath.Join("inputs", "2022", "day05.txt")},
+		{"AnswersPath", day.AnswersPath(), filepath.Join("inputs", "2022", "day05.answers.json")},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, test.got, test.want)
+		}
+	}
+}
+
+func getDayIDs(days []Day) []string {
+	ids := make([]string, len(days))
+	for idx, day := range days {
+		ids[idx] = day.ID()
+	}
+	return ids
+}
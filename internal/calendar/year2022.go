@@ -0,0 +1,25 @@
+package calendar
+
+func init() {
+	Register(2022,
+		Day{Number: 1, Title: "Calorie Counting"},
+		Day{Number: 2, Title: "Rock Paper Scissors"},
+		Day{Number: 3, Title: "Rucksack Reorganization"},
+		Day{Number: 4, Title: "Camp Cleanup"},
+		Day{Number: 5, Title: "Supply Stacks"},
+		Day{Number: 6, Title: "Tuning Trouble"},
+		Day{Number: 7, Title: "No Space Left On Device"},
+		Day{Number: 8, Title: "Treetop Tree House"},
+		Day{Number: 9, Title: "Rope Bridge"},
+		Day{Number: 10, Title: "Cathode-Ray Tube"},
+		Day{Number: 11, Title: "Monkey in the Middle", NoInput: true, DiffArgs: []string{"-param", "rounds=100"}},
+		Day{Number: 12, Title: "Hill Climbing Algorithm"},
+		Day{Number: 13, Title: "Distress Signal"},
+		Day{Number: 14, Title: "Regolith Reservoir"},
+		Day{Number: 15, Title: "Beacon Exclusion Zone"},
+		Day{Number: 17, Title: "Pyroclastic Flow"},
+		Day{Number: 18, Title: "Boiling Boulders"},
+		Day{Number: 20, Title: "Grove Positioning System"},
+		Day{Number: 21, Title: "Monkey Math"},
+	)
+}
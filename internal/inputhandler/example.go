@@ -0,0 +1,70 @@
+package inputhandler
+
+import (
+	"strings"
+)
+
+// Example is the worked example from the puzzle's description, with the answers given there.
+// The days embed the input from their example.txt and set it with SetExample().
+type Example struct {
+	Input   string            // the example input as it is in the description
+	Answers map[string]string // by part like "Part1", images with the lines joined with '\n'
+	Params  map[string]int    // the puzzle's constants that are different for the example, see GetParam()
+}
+
+var dayExample *Example
+
+// SetExample sets the worked example of the day, solved instead of an input with -example.
+func SetExample(example Example) {
+	dayExample = &example
+}
+
+// GetExample returns the day's worked example, nil if it has none.
+func GetExample() *Example {
+	return dayExample
+}
+
+// IsExampleRequested tells if the worked example is solved instead of an input.
+func IsExampleRequested() bool {
+	_ = ParseFlags()
+	method, _, err := ParseCommandLine()
+	return err == nil && method == InputExample
+}
+
+// GetParam returns the value of the puzzle's constant: the one set with -param, the example's
+// when the example is solved, or the value for the real input otherwise. Like the row to
+// check on day 15:
+//
+//	row := inputhandler.GetParam("row", 2000000)
+func GetParam(name string, inputValue int) int {
+
+	_ = ParseFlags()
+	if value, ok := paramOverrides[name]; ok {
+		return value
+	}
+
+	if !IsExampleRequested() || dayExample == nil {
+		return inputValue
+	}
+	if value, ok := dayExample.Params[name]; ok {
+		return value
+	}
+
+	return inputValue
+}
+
+// GetExpectedAnswer returns the example's answer of the part when the example is solved.
+func GetExpectedAnswer(part string) (string, bool) {
+
+	if !IsExampleRequested() || dayExample == nil {
+		return "", false
+	}
+	answer, ok := dayExample.Answers[part]
+
+	return answer, ok
+}
+
+// getExampleLines returns the lines of the example input
+func getExampleLines() []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(dayExample.Input, "\r\n", "\n"), "\n"), "\n")
+}
@@ -0,0 +1,133 @@
+package inputhandler
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var generateFlag = flag.Bool("gen", false, "print a random input for the puzzle instead of solving it, see -seed and -size")
+var generateSeed = flag.Int64("seed", 0, "seed of -gen, the same seed gives the same input (default random)")
+var generateSize = flag.Int("size", 0, "size of the input of -gen, like the number of lines (default is about a real input's)")
+
+// Generated is a random input, with the puzzle's constants it has to be solved with.
+type Generated struct {
+	Lines  []string
+	Params map[string]int // see GetParam(), nil if the defaults are fine
+}
+
+// Generator creates a random valid input of about the given size, only using rng
+// for the randomness so the same seed gives the same input.
+type Generator func(rng *rand.Rand, size int) Generated
+
+type dayGenerator struct {
+	generate    Generator
+	defaultSize int
+}
+
+var registeredGenerator *dayGenerator
+
+// ErrorNoGenerator is returned by Generate() for days without a generator.
+var ErrorNoGenerator = fmt.Errorf("no input generator")
+
+// SetGenerator sets the day's random input generator, used with -gen.
+func SetGenerator(defaultSize int, generate Generator) {
+	registeredGenerator = &dayGenerator{generate: generate, defaultSize: defaultSize}
+}
+
+// IsGenerateRequested tells if a random input should be printed instead of solving.
+func IsGenerateRequested() bool {
+	_ = ParseFlags()
+	return *generateFlag
+}
+
+// Generate creates a random input with the day's generator, size 0 is the default size.
+func Generate(seed int64, size int) (Generated, error) {
+
+	if registeredGenerator == nil {
+		return Generated{}, ErrorNoGenerator
+	}
+	if size <= 0 {
+		size = registeredGenerator.defaultSize
+	}
+
+	return registeredGenerator.generate(rand.New(rand.NewSource(seed)), size), nil
+}
+
+// printGenerated prints the random input asked for on the commandline to stdout.
+// The seed and the parameters go to stderr, to reproduce and solve the input.
+func printGenerated() {
+
+	seed := *generateSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	generated, err := Generate(seed, *generateSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		Exit(ErrorCodeParameters)
+	}
+
+	fmt.Fprintf(os.Stderr, "seed: %d\n", seed)
+	if params := FormatParams(generated.Params); len(params) > 0 {
+		fmt.Fprintf(os.Stderr, "solve with: %s\n", strings.Join(params, " "))
+	}
+
+	for _, line := range generated.Lines {
+		fmt.Println(line)
+	}
+}
+
+//-Parameters------------------------------------------------------------------
+
+// paramsFlag collects the -param name=value flags
+type paramsFlag map[string]int
+
+func (f paramsFlag) String() string {
+	return strings.Join(FormatParams(f), " ")
+}
+
+func (f paramsFlag) Set(value string) error {
+
+	name, valueText, found := strings.Cut(value, "=")
+	if !found || len(name) == 0 {
+		return fmt.Errorf("invalid parameter '%s', use name=value", value)
+	}
+
+	paramValue, err := strconv.Atoi(valueText)
+	if err != nil {
+		return fmt.Errorf("invalid value of parameter '%s': '%s'", name, valueText)
+	}
+	f[name] = paramValue
+
+	return nil
+}
+
+var paramOverrides = make(paramsFlag)
+
+func init() {
+	flag.Var(paramOverrides, "param", "set a constant of the puzzle like `name=value`, for generated inputs (repeatable)")
+}
+
+// FormatParams returns the parameters as -param flags in order.
+func FormatParams(params map[string]int) []string {
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	flags := make([]string, 0, len(names))
+	for _, name := range names {
+		flags = append(flags, fmt.Sprintf("-param %s=%d", name, params[name]))
+	}
+
+	return flags
+}
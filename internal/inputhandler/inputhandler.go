@@ -5,57 +5,101 @@
 package inputhandler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 )
 
 // ReadInput is a one call method to parse the commandline and return the input data as separate lines.
 // On any caught error, it will exit the app with an error text.
+// With -gen, it prints a random input instead and exits, see SetGenerator().
 func ReadInput() []string {
 
+	if IsGenerateRequested() {
+		printGenerated()
+		Exit(0)
+	}
+	if IsReferenceRequested() && dayReference == nil {
+		fmt.Printf("Error: %v for this day\n", ErrorNoReference)
+		Exit(ErrorCodeParameters)
+	}
+
 	inputMethod, paramValue, err := ParseCommandLine()
 	if err != nil {
 		fmt.Printf("Error while parsing command line: %v\n\n", err)
-		fmt.Println("Usage: cmd -[p/f/w] [data/uri]")
-		fmt.Println("p - data is provided as a ';' separated value")
-		fmt.Println("f - data is in the file pointed to by the provided path")
-		fmt.Println("w - data is given by a website pointed to by the provided url")
-		os.Exit(int(ErrorCodeParameters))
+		fmt.Println("Usage: cmd -[p/f/w] [data/uri] [options] or cmd -example [options]")
+		flag.CommandLine.SetOutput(os.Stdout)
+		flag.PrintDefaults()
+		Exit(ErrorCodeParameters)
 	}
 
 	var lines []string
 	switch inputMethod {
 	case InputParameters:
 		lines = strings.Split(paramValue, ";")
+		inputSource = "command line"
 
 	case InputFile:
 		inputData, err := GetDataFromFile(paramValue)
 		if err != nil {
-			fmt.Printf("Error while reading from file '%s': %v", paramValue, err)
-			os.Exit(int(ErrorCodeFiles))
+			fmt.Printf("Error while reading from file '%s': %v\n", paramValue, err)
+			Exit(ErrorCodeFiles)
 		}
 		lines = strings.Split(strings.TrimSuffix(inputData, "\n"), "\n")
+		inputSource = paramValue
 
 	case InputWebpage:
+		paramValue = resolveInputURL(paramValue)
 		inputData, err := GetDataFromWebpage(paramValue)
 		if err != nil {
-			fmt.Printf("Error while reading from URL '%s': %v", paramValue, err)
-			os.Exit(int(ErrorCodeNetwork))
+			fmt.Printf("Error while reading from URL '%s': %v\n", paramValue, err)
+			Exit(ErrorCodeNetwork)
 		}
 		lines = strings.Split(strings.TrimSuffix(inputData, "\n"), "\n")
+		inputSource = paramValue
+
+	case InputExample:
+		if dayExample == nil {
+			fmt.Println("Error: this day has no example")
+			Exit(ErrorCodeParameters)
+		}
+		lines = getExampleLines()
+		inputSource = "example"
 
 	}
 	if len(lines) == 0 {
 		fmt.Println("Error: no data was given")
-		os.Exit(4)
+		Exit(ErrorCodeData)
 	}
 
+	checksum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	inputChecksum = hex.EncodeToString(checksum[:])
+
 	return lines
 }
 
+var inputSource string
+var inputChecksum string
+
+// GetInputChecksum returns the SHA-256 of the lines ReadInput() returned, joined with '\n',
+// so the same data has the same checksum whichever way it was given.
+// Empty before ReadInput() is called.
+func GetInputChecksum() string {
+	return inputChecksum
+}
+
+// GetInputSource returns where ReadInput() got the data from: the file path, the URL,
+// or "command line". Empty before ReadInput() is called.
+func GetInputSource() string {
+	return inputSource
+}
+
 // ErrorCodes is the suggested application exit codes.
 // Your code can use ErrorCodeProcessing just for consistency.
 type ErrorCodes int
@@ -66,6 +110,7 @@ const (
 	ErrorCodeNetwork    ErrorCodes = 3
 	ErrorCodeData       ErrorCodes = 4
 	ErrorCodeProcessing ErrorCodes = 5
+	ErrorCodeStopped    ErrorCodes = 6 // by a timeout or Ctrl-C, see GetContext()
 )
 
 // InputMethod is the determined input method from the commandline arguments.
@@ -76,31 +121,70 @@ const (
 	InputParameters InputMethod = "InputParameters"
 	InputFile       InputMethod = "InputFile"
 	InputWebpage    InputMethod = "InputWebpage"
+	InputExample    InputMethod = "InputExample" // see SetExample()
 )
 
 // ErrorInvalidParameters returnde by ParseCommandLine when it faild to parse parameters.
 var ErrorInvalidParameters = fmt.Errorf("invalid parameters")
 
+var inputFlags = map[string]InputMethod{
+	"p": InputParameters,
+	"f": InputFile,
+	"w": InputWebpage,
+
+	"example": InputExample,
+}
+
+func init() {
+	flag.String("p", "", "data is provided as a ';' separated value")
+	flag.String("f", "", "data is in the file pointed to by the provided path")
+	flag.String("w", "", "data is given by a website pointed to by the provided url, or like 2022/1 for the day's input")
+	flag.Bool("example", false, "solve the worked example of the puzzle instead and check its answers")
+
+	// errors and usage are printed by ReadInput()
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	flag.CommandLine.SetOutput(io.Discard)
+}
+
+var parseFlagsOnce sync.Once
+var parseFlagsErr error
+
+// ParseFlags parses the commandline flags registered by any of the packages.
+// Only the first call does the parsing, later ones return the same result.
+func ParseFlags() error {
+	parseFlagsOnce.Do(func() {
+		parseFlagsErr = flag.CommandLine.Parse(os.Args[1:])
+	})
+	return parseFlagsErr
+}
+
 // ParseCommandLine is the commandline parser.
 // It returns the determined input method, the associated parameter value, or the error if any.
 func ParseCommandLine() (InputMethod, string, error) {
 
-	args := os.Args[1:]
-
-	if len(args) < 2 {
-		return InputInvalid, "", ErrorInvalidParameters
+	if err := ParseFlags(); err != nil {
+		return InputInvalid, "", fmt.Errorf("%w: %v", ErrorInvalidParameters, err)
 	}
 
-	switch args[0] {
-	case "-p":
-		return InputParameters, args[1], nil
-	case "-f":
-		return InputFile, args[1], nil
-	case "-w":
-		return InputWebpage, args[1], nil
+	var inputMethod = InputInvalid
+	var paramValue string
+	var methodCount int
+	flag.Visit(func(f *flag.Flag) {
+		if method, ok := inputFlags[f.Name]; ok {
+			if method == InputExample && f.Value.String() == "false" {
+				return
+			}
+			inputMethod = method
+			paramValue = f.Value.String()
+			methodCount++
+		}
+	})
+
+	if methodCount != 1 {
+		return InputInvalid, "", ErrorInvalidParameters
 	}
 
-	return InputInvalid, "", ErrorInvalidParameters
+	return inputMethod, paramValue, nil
 }
 
 // GetDataFromFile will try to open the file at the given path and returns it's contents or an error if any.
@@ -0,0 +1,135 @@
+package inputhandler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is an error in the input data, with the position it was found at.
+// Line and column numbers are 1-based like in editors, 0 means unknown. The column
+// counts characters (runes), not bytes.
+type ParseError struct {
+	Source  string // where the input came from, see GetInputSource()
+	Line    int
+	Column  int
+	Text    string // the line the error is in, for the snippet
+	Message string
+	Err     error // the underlying error, if any
+}
+
+// NewParseError creates the error for the line at lineIdx of the input lines, use
+// lineIdx -1 with an empty line for the errors of the whole input, like a missing part.
+// columnIdx is the byte index of the problematic part in the line, like the ones
+// strings.Index() and ranging over the line give, -1 if unknown. It's turned into
+// the column of the character there.
+// The message is formatted like fmt.Errorf(), so %w can be used to wrap an error.
+func NewParseError(lineIdx int, line string, columnIdx int, format string, args ...interface{}) *ParseError {
+
+	err := fmt.Errorf(format, args...)
+
+	var column int
+	switch {
+	case columnIdx < 0:
+	case columnIdx > len(line): // past the end, like a missing field
+		column = utf8.RuneCountInString(line) + columnIdx - len(line) + 1
+	default:
+		column = utf8.RuneCountInString(line[:columnIdx]) + 1
+	}
+
+	return &ParseError{
+		Source:  GetInputSource(),
+		Line:    lineIdx + 1,
+		Column:  column,
+		Text:    line,
+		Message: err.Error(),
+		Err:     errors.Unwrap(err),
+	}
+}
+
+// Error returns the error in the usual "source:line:column: message" format.
+func (e *ParseError) Error() string {
+
+	position := e.Source
+	if len(position) == 0 {
+		position = "input"
+	}
+	if e.Line > 0 {
+		position += fmt.Sprintf(":%d", e.Line)
+		if e.Column > 0 {
+			position += fmt.Sprintf(":%d", e.Column)
+		}
+	}
+
+	return position + ": " + e.Message
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Snippet returns the line with a caret under the column, like:
+//
+//	5 | move x from 1 to 2
+//	  |      ^
+//
+// Returns an empty string if there is no line to show.
+func (e *ParseError) Snippet() string {
+
+	if e.Line <= 0 {
+		return ""
+	}
+
+	lineNumber := fmt.Sprintf("%3d", e.Line)
+	snippet := fmt.Sprintf("%s | %s", lineNumber, e.Text)
+	if e.Column > 0 {
+		// tabs are kept, so the caret lines up in the terminal
+		padding := strings.Map(func(char rune) rune {
+			if char == '\t' {
+				return '\t'
+			}
+			return ' '
+		}, string([]rune(e.Text)[:getMin(e.Column-1, len([]rune(e.Text)))]))
+		snippet += fmt.Sprintf("\n%s | %s^", strings.Repeat(" ", len(lineNumber)), padding)
+	}
+
+	return snippet
+}
+
+// FindColumn returns the byte index of the first occurrence of the token in the line,
+// for NewParseError(). Returns -1 if not found.
+func FindColumn(line string, token string) int {
+
+	if len(token) == 0 {
+		return -1
+	}
+
+	return strings.Index(line, token)
+}
+
+// FieldColumn returns the byte index of the whitespace separated field of the line,
+// for NewParseError(). Returns -1 if there are not enough fields.
+func FieldColumn(line string, fieldIdx int) int {
+
+	inField := false
+	for charIdx, char := range line {
+		isSpace := char == ' ' || char == '\t'
+		if !isSpace && !inField {
+			if fieldIdx == 0 {
+				return charIdx
+			}
+			fieldIdx--
+		}
+		inField = !isSpace
+	}
+
+	return -1
+}
+
+func getMin(val1, val2 int) int {
+	if val1 < val2 {
+		return val1
+	}
+	return val2
+}
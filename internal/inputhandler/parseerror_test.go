@@ -0,0 +1,107 @@
+package inputhandler
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParseErrorFormat(t *testing.T) {
+
+	tests := []struct {
+		err         *ParseError
+		wantError   string
+		wantSnippet string
+	}{
+		{
+			NewParseError(4, "move x from 1 to 2", 5, "invalid move count '%s'", "x"),
+			"input:5:6: invalid move count 'x'",
+			"  5 | move x from 1 to 2\n    |      ^",
+		},
+		{ // the column counts characters, the index is in bytes
+			NewParseError(0, "árvíz tűrő x", 15, "invalid item '%s'", "x"),
+			"input:1:12: invalid item 'x'",
+			"  1 | árvíz tűrő x\n    |            ^",
+		},
+		{ // tabs are kept so the caret lines up
+			NewParseError(11, "\tab\tc", 4, "invalid field"),
+			"input:12:5: invalid field",
+			" 12 | \tab\tc\n    | \t  \t^",
+		},
+		{ // past the end of the line, like a missing field
+			NewParseError(0, "1,2", 4, "missing Z coord"),
+			"input:1:5: missing Z coord",
+			"  1 | 1,2\n    |    ^",
+		},
+		{
+			NewParseError(99, "whole line", -1, "invalid line"),
+			"input:100: invalid line",
+			"100 | whole line",
+		},
+		{
+			NewParseError(-1, "", -1, "no start"),
+			"input: no start",
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.err.Error(); got != test.wantError {
+			t.Errorf("Error(): got %q, want %q", got, test.wantError)
+		}
+		if got := test.err.Snippet(); got != test.wantSnippet {
+			t.Errorf("Snippet() of %q: got %q, want %q", test.wantError, got, test.wantSnippet)
+		}
+	}
+}
+
+func TestParseErrorSource(t *testing.T) {
+
+	err := NewParseError(6, "a", 0, "invalid")
+	err.Source = "input.txt"
+
+	if got, want := err.Error(), "input.txt:7:1: invalid"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseErrorUnwrap(t *testing.T) {
+
+	_, convErr := strconv.Atoi("x")
+	err := NewParseError(0, "x", 0, "invalid number: %w", convErr)
+
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("%v doesn't wrap %v", err, strconv.ErrSyntax)
+	}
+}
+
+func TestColumns(t *testing.T) {
+
+	line := "égé  rő\tx"
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"FieldColumn 0", FieldColumn(line, 0), 0},
+		{"FieldColumn 1", FieldColumn(line, 1), 7},
+		{"FieldColumn 2", FieldColumn(line, 2), 11},
+		{"FieldColumn 3", FieldColumn(line, 3), -1},
+		{"FieldColumn leading space", FieldColumn("  a", 0), 2},
+		{"FindColumn", FindColumn(line, "x"), 11},
+		{"FindColumn missing", FindColumn(line, "y"), -1},
+		{"FindColumn empty", FindColumn(line, ""), -1},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %d, want %d", test.name, test.got, test.want)
+		}
+	}
+
+	// the byte indexes point at the same characters as the fields
+	if err := NewParseError(0, line, FieldColumn(line, 2), "x"); err.Column != 9 {
+		t.Errorf("got column %d, want 9", err.Column)
+	}
+}
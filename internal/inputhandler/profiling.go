@@ -0,0 +1,233 @@
+package inputhandler
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"strings"
+)
+
+var cpuProfileFile = flag.String("cpuprofile", "", "write a CPU profile to the `file`, for go tool pprof")
+var memProfileFile = flag.String("memprofile", "", "write a heap profile to the `file` and print the top allocation sites after the run")
+var execTraceFile = flag.String("exectrace", "", "write an execution trace to the `file`, for go tool trace")
+
+type profiling struct {
+	cpuFile   *os.File
+	traceFile *os.File
+}
+
+var activeProfiling *profiling
+
+// StartProfiling starts the profiles requested on the commandline.
+// Profiles that can't be started are skipped, the errors are returned together.
+func StartProfiling() error {
+
+	_ = ParseFlags()
+
+	if activeProfiling != nil {
+		return nil
+	}
+	activeProfiling = &profiling{}
+
+	errs := make([]string, 0)
+
+	if len(*cpuProfileFile) > 0 {
+		file, err := os.Create(*cpuProfileFile)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("couldn't create file '%s': %v", *cpuProfileFile, err))
+		} else if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			errs = append(errs, fmt.Sprintf("couldn't start CPU profile: %v", err))
+		} else {
+			activeProfiling.cpuFile = file
+		}
+	}
+
+	if len(*execTraceFile) > 0 {
+		file, err := os.Create(*execTraceFile)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("couldn't create file '%s': %v", *execTraceFile, err))
+		} else if err := trace.Start(file); err != nil {
+			file.Close()
+			errs = append(errs, fmt.Sprintf("couldn't start execution trace: %v", err))
+		} else {
+			activeProfiling.traceFile = file
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// StopProfiling stops the profiles and writes the heap profile.
+func StopProfiling() error {
+
+	if activeProfiling == nil {
+		return nil
+	}
+
+	errs := make([]string, 0)
+
+	if activeProfiling.cpuFile != nil {
+		pprof.StopCPUProfile()
+		if err := activeProfiling.cpuFile.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("couldn't write file '%s': %v", *cpuProfileFile, err))
+		}
+	}
+
+	if activeProfiling.traceFile != nil {
+		trace.Stop()
+		if err := activeProfiling.traceFile.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("couldn't write file '%s': %v", *execTraceFile, err))
+		}
+	}
+
+	if len(*memProfileFile) > 0 {
+		if err := writeHeapProfile(*memProfileFile); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	activeProfiling = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func writeHeapProfile(path string) error {
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("couldn't create file '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	runtime.GC() // up-to-date statistics
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("couldn't write file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+//-Allocation sites------------------------------------------------------------
+
+// AllocationSite is where the solution allocated memory during the run.
+// The numbers are estimates from the sampled memory profile, like in pprof.
+type AllocationSite struct {
+	Function string
+	File     string
+	Line     int
+	Bytes    int64
+	Objects  int64
+}
+
+// IsMemProfileRequested tells if the heap profile and the allocation summary were requested.
+func IsMemProfileRequested() bool {
+	_ = ParseFlags()
+	return len(*memProfileFile) > 0
+}
+
+// GetTopAllocationSites returns the places that allocated the most bytes so far,
+// at most count of them. Allocations are attributed to the first function of the
+// solution in the call stack, so the ones in strings.Split and alike show up
+// where they were called from.
+func GetTopAllocationSites(count int) []AllocationSite {
+
+	runtime.GC()
+
+	var records []runtime.MemProfileRecord
+	size, _ := runtime.MemProfile(nil, true)
+	for {
+		records = make([]runtime.MemProfileRecord, size+50)
+		var ok bool
+		size, ok = runtime.MemProfile(records, true)
+		if ok {
+			records = records[:size]
+			break
+		}
+	}
+
+	sites := make(map[string]*AllocationSite)
+	for _, record := range records {
+
+		frame, found := getAllocationFrame(record.Stack())
+		if !found {
+			continue
+		}
+
+		bytes, objects := scaleMemProfileSample(record.AllocBytes, record.AllocObjects)
+
+		key := fmt.Sprintf("%s:%d", frame.Function, frame.Line)
+		site, ok := sites[key]
+		if !ok {
+			site = &AllocationSite{Function: frame.Function, File: filepath.Base(frame.File), Line: frame.Line}
+			sites[key] = site
+		}
+		site.Bytes += bytes
+		site.Objects += objects
+	}
+
+	topSites := make([]AllocationSite, 0, len(sites))
+	for _, site := range sites {
+		topSites = append(topSites, *site)
+	}
+	sort.Slice(topSites, func(i, j int) bool {
+		return topSites[i].Bytes > topSites[j].Bytes
+	})
+	if len(topSites) > count {
+		topSites = topSites[:count]
+	}
+
+	return topSites
+}
+
+// getAllocationFrame finds the first frame of package main in the stack,
+// or the first one outside the runtime if the allocation isn't from the solution.
+func getAllocationFrame(stack []uintptr) (runtime.Frame, bool) {
+
+	var fallback runtime.Frame
+	var haveFallback bool
+
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, "main.") {
+			return frame, true
+		}
+		if !haveFallback && len(frame.Function) > 0 && !strings.HasPrefix(frame.Function, "runtime.") {
+			fallback, haveFallback = frame, true
+		}
+		if !more {
+			break
+		}
+	}
+
+	return fallback, haveFallback
+}
+
+// scaleMemProfileSample estimates the real numbers from the sampled ones,
+// the same way pprof does.
+func scaleMemProfileSample(bytes, objects int64) (int64, int64) {
+
+	if objects == 0 || bytes == 0 || runtime.MemProfileRate <= 1 {
+		return bytes, objects
+	}
+
+	avgSize := float64(bytes) / float64(objects)
+	scale := 1 / (1 - math.Exp(-avgSize/float64(runtime.MemProfileRate)))
+
+	return int64(float64(bytes) * scale), int64(float64(objects) * scale)
+}
@@ -0,0 +1,45 @@
+package inputhandler
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// InputStoreDir is where the personal inputs are kept by year, relative to the module root.
+// Keep it out of the repository, the inputs are not to be shared.
+const InputStoreDir = "inputs"
+
+// GetStoredInputPath returns where the day's input is kept, like "inputs/2022/day01.txt".
+func GetStoredInputPath(year, day int) string {
+	return filepath.Join(InputStoreDir, strconv.Itoa(year), fmt.Sprintf("day%02d.txt", day))
+}
+
+// GetInputURL returns the URL of the day's personal input on the Advent of Code site.
+func GetInputURL(year, day int) string {
+	return fmt.Sprintf("https://adventofcode.com/%d/day/%d/input", year, day)
+}
+
+// GetLeaderboardURL returns the URL of the private leaderboard's JSON on the Advent of Code site.
+// The ID is in the leaderboard's URL, and it's the ID of its owner.
+func GetLeaderboardURL(year, id int) string {
+	return fmt.Sprintf("https://adventofcode.com/%d/leaderboard/private/view/%d.json", year, id)
+}
+
+var inputShorthandPattern = regexp.MustCompile(`^(\d{4})/(?:day)?(\d{1,2})$`)
+
+// resolveInputURL turns the "2022/1" shorthand given to -w into the URL of the input,
+// other values are returned as they are.
+func resolveInputURL(value string) string {
+
+	match := inputShorthandPattern.FindStringSubmatch(value)
+	if match == nil {
+		return value
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	day, _ := strconv.Atoi(match[2])
+
+	return GetInputURL(year, day)
+}
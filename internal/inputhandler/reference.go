@@ -0,0 +1,34 @@
+package inputhandler
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+var referenceFlag = flag.Bool("reference", false, "solve with the day's naive reference solver instead, slow but simple enough to trust")
+
+// ReferenceSolver solves a part of the puzzle the slow and obvious way, to check the
+// real solution's shortcuts against. The answer is like the one given to Results.Add().
+type ReferenceSolver func(ctx context.Context, lines []string, part int) (interface{}, error)
+
+var dayReference ReferenceSolver
+
+// ErrorNoReference is returned for days and parts without a reference solver.
+var ErrorNoReference = fmt.Errorf("no reference solver")
+
+// SetReference sets the day's reference solver, used with -reference.
+func SetReference(solve ReferenceSolver) {
+	dayReference = solve
+}
+
+// GetReference returns the day's reference solver, nil if it has none.
+func GetReference() ReferenceSolver {
+	return dayReference
+}
+
+// IsReferenceRequested tells if the parts should be solved with the reference solver.
+func IsReferenceRequested() bool {
+	_ = ParseFlags()
+	return *referenceFlag
+}
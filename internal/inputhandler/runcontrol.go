@@ -0,0 +1,145 @@
+package inputhandler
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// partFlag is the part to solve, 0 means both
+type partFlag int
+
+func (f *partFlag) String() string {
+	if *f == 0 {
+		return "both"
+	}
+	return fmt.Sprint(int(*f))
+}
+
+func (f *partFlag) Set(value string) error {
+
+	switch value {
+	case "1":
+		*f = 1
+	case "2":
+		*f = 2
+	case "both", "all":
+		*f = 0
+	default:
+		return fmt.Errorf("invalid part '%s', use 1, 2 or both", value)
+	}
+
+	return nil
+}
+
+var requestedPart partFlag
+var timeout = flag.Duration("timeout", 0, "stop solving after the `duration`, like 30s or 2m (default no limit)")
+
+func init() {
+	flag.Var(&requestedPart, "part", "solve only part 1 or 2")
+}
+
+// IsPartRequested tells if the part (1 or 2) should be solved.
+func IsPartRequested(part int) bool {
+	_ = ParseFlags()
+	return requestedPart == 0 || int(requestedPart) == part
+}
+
+var runStart = time.Now()
+var runContext context.Context
+var runContextOnce sync.Once
+
+// GetContext returns the context for the solvers. It's canceled when the timeout set
+// on the commandline is over (counted from the start of the app) or on Ctrl-C, the
+// solvers should stop then and return what they have so far. A second Ctrl-C kills
+// the app as usual.
+func GetContext() context.Context {
+
+	runContextOnce.Do(func() {
+		_ = ParseFlags()
+
+		var cancel context.CancelFunc
+		if *timeout > 0 {
+			runContext, cancel = context.WithDeadline(context.Background(), runStart.Add(*timeout))
+		} else {
+			runContext, cancel = context.WithCancel(context.Background())
+		}
+
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		go func() {
+			select {
+			case <-interrupt:
+			case <-runContext.Done():
+			}
+			signal.Stop(interrupt)
+			cancel()
+		}()
+	})
+
+	return runContext
+}
+
+// stopReason is why a solver was stopped, see SetStopped(). Empty while none was.
+var stopReason string
+
+// SetStopped records that a solver returned early because the context of GetContext()
+// was canceled. Only these runs count as stopped: the context can also be canceled
+// after the solvers are done, like while the dashboard or the explorer waits.
+func SetStopped() {
+
+	if len(stopReason) > 0 {
+		return
+	}
+
+	stopReason = "stopped"
+	if runContext != nil {
+		switch runContext.Err() {
+		case context.DeadlineExceeded:
+			stopReason = fmt.Sprintf("timed out after %v", *timeout)
+		case context.Canceled:
+			stopReason = "interrupted"
+		}
+	}
+}
+
+// IsStopped tells if a solver was stopped by a timeout or Ctrl-C, see SetStopped().
+func IsStopped() bool {
+	return len(stopReason) > 0
+}
+
+// IsStopError tells if the error is from the context of GetContext(), i.e. the
+// solver was stopped rather than failed.
+func IsStopError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// GetStopReason returns why the solvers were stopped, for the messages.
+func GetStopReason() string {
+	return stopReason
+}
+
+var exitHandlers []func()
+
+// OnExit registers a function that Exit() calls before the app exits, like the one
+// that saves the captured output.
+func OnExit(handler func()) {
+	exitHandlers = append(exitHandlers, handler)
+}
+
+// Exit calls the functions registered with OnExit(), the last one first, and exits
+// the app with the code. Use it instead of os.Exit(), which skips the deferred calls
+// that would save the recordings and restore the terminal.
+func Exit(code ErrorCodes) {
+
+	for idx := len(exitHandlers) - 1; idx >= 0; idx-- {
+		exitHandlers[idx]()
+	}
+
+	os.Exit(int(code))
+}
@@ -0,0 +1,41 @@
+package inputhandler
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestStoppedOnlyBySolvers(t *testing.T) {
+
+	if runtime.GOOS == "windows" {
+		t.Skip("can't send an interrupt to itself")
+	}
+	defer func() { stopReason = "" }()
+
+	ctx := GetContext()
+
+	// a Ctrl-C after the solvers are done, like while the dashboard waits
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the context wasn't canceled by the interrupt")
+	}
+
+	if IsStopped() || len(GetStopReason()) > 0 {
+		t.Errorf("got stopped '%s' without a stopped solver", GetStopReason())
+	}
+
+	SetStopped()
+	if !IsStopped() || GetStopReason() != "interrupted" {
+		t.Errorf("got stopped %v with reason '%s', want stopped with 'interrupted'", IsStopped(), GetStopReason())
+	}
+}
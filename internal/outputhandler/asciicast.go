@@ -0,0 +1,134 @@
+package outputhandler
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var recordFile = flag.String("record", "", "record the output to an asciicast v2 `file` (e.g. day14.cast)")
+
+// asciicastHeader is the first line of an asciicast v2 file.
+// See: https://docs.asciinema.org/manual/asciicast/v2/
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// asciicastWriter writes everything written to it as timestamped output events,
+// so the run can be replayed with asciinema or any other asciicast player.
+type asciicastWriter struct {
+	path    string
+	file    *os.File
+	buf     *bufio.Writer
+	start   time.Time
+	pending []byte // an UTF-8 sequence split between two writes
+}
+
+func newAsciicastWriter(path string, width, height int) (*asciicastWriter, error) {
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create file '%s': %w", path, err)
+	}
+
+	cast := &asciicastWriter{
+		path:  path,
+		file:  file,
+		buf:   bufio.NewWriter(file),
+		start: time.Now(),
+	}
+
+	header := asciicastHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: cast.start.Unix(),
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	}
+	// the header is written right away, so even a run that fails early leaves a valid file
+	if err := cast.writeLine(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := cast.buf.Flush(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("couldn't write file '%s': %w", path, err)
+	}
+
+	return cast, nil
+}
+
+func (c *asciicastWriter) Write(p []byte) (int, error) {
+
+	data := append(c.pending, p...)
+	c.pending = nil
+
+	// keep an incomplete rune for the next write, events have to be valid UTF-8
+	for idx := len(data) - 1; idx >= 0 && idx >= len(data)-utf8.UTFMax; idx-- {
+		if utf8.RuneStart(data[idx]) {
+			if !utf8.FullRune(data[idx:]) {
+				c.pending = append([]byte{}, data[idx:]...)
+				data = data[:idx]
+			}
+			break
+		}
+	}
+
+	if err := c.writeEvent(data); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (c *asciicastWriter) Close() error {
+
+	err := c.writeEvent(c.pending)
+	if flushErr := c.buf.Flush(); flushErr != nil && err == nil {
+		err = fmt.Errorf("couldn't write file '%s': %w", c.path, flushErr)
+	}
+	if closeErr := c.file.Close(); closeErr != nil && err == nil {
+		err = fmt.Errorf("couldn't close file '%s': %w", c.path, closeErr)
+	}
+
+	return err
+}
+
+func (c *asciicastWriter) writeEvent(data []byte) error {
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	// the terminal driver would turn "\n" into "\r\n", players don't
+	text := strings.ToValidUTF8(string(data), "�")
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+
+	elapsed := float64(time.Since(c.start).Microseconds()) / 1e6
+
+	return c.writeLine([]interface{}{elapsed, "o", text})
+}
+
+func (c *asciicastWriter) writeLine(value interface{}) error {
+
+	line, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("couldn't encode asciicast event: %w", err)
+	}
+
+	line = append(line, '\n')
+	if _, err := c.buf.Write(line); err != nil {
+		return fmt.Errorf("couldn't write file '%s': %w", c.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package outputhandler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestAsciicastWriter(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "test.cast")
+	cast, err := newAsciicastWriter(path, 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "█" is 3 bytes, split after the first one, and the color is split in the middle
+	block := "█"
+	writes := []string{
+		"rock " + block[:1],
+		block[1:] + " \x1b[3",
+		"1mred\x1b[0m\n",
+	}
+	for _, write := range writes {
+		if n, err := cast.Write([]byte(write)); err != nil || n != len(write) {
+			t.Fatalf("Write(%q) = %d, %v, want %d, nil", write, n, err, len(write))
+		}
+	}
+	if err := cast.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+
+	var header asciicastHeader
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("invalid header %q: %v", lines[0], err)
+	}
+	if header.Version != 2 || header.Width != 80 || header.Height != 24 {
+		t.Errorf("got header %+v, want version 2 and 80x24", header)
+	}
+
+	// the partial rune waits for the next write, the escape sequence doesn't have to
+	wantEvents := []string{"rock ", block + " \x1b[3", "1mred\x1b[0m\r\n"}
+	var events []string
+	lastTime := 0.0
+	for _, line := range lines[1:] {
+		var event []interface{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil || len(event) != 3 {
+			t.Fatalf("invalid event %q: %v", line, err)
+		}
+		elapsed, _ := event[0].(float64)
+		kind, _ := event[1].(string)
+		text, _ := event[2].(string)
+		if kind != "o" || elapsed < lastTime {
+			t.Errorf("got event %q, want an output event after %v", line, lastTime)
+		}
+		if !utf8.ValidString(text) || strings.ContainsRune(text, utf8.RuneError) {
+			t.Errorf("got event text %q, want whole characters", text)
+		}
+		lastTime = elapsed
+		events = append(events, text)
+	}
+
+	if strings.Join(events, "|") != strings.Join(wantEvents, "|") {
+		t.Errorf("got events %q, want %q", events, wantEvents)
+	}
+}
+
+func TestAsciicastWriterPendingOnClose(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "test.cast")
+	cast, err := newAsciicastWriter(path, 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a rune that never gets finished is still written, as the replacement character
+	if _, err := cast.Write([]byte("end " + "█"[:2])); err != nil {
+		t.Fatal(err)
+	}
+	if err := cast.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[1], `"o","end "]`) || !strings.HasSuffix(lines[2], `"o","�"]`) {
+		t.Errorf("got %q, want the text and then the replacement character", lines)
+	}
+}
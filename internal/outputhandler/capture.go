@@ -0,0 +1,92 @@
+package outputhandler
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// terminalOutput is the real standard output. os.Stdout is replaced with a pipe
+// while the output is captured, but the console calls need the terminal itself.
+var terminalOutput = os.Stdout
+
+// outputCapture sits between os.Stdout and the terminal. Everything the solutions
+// print is passed to the terminal as is and copied to the capture writers
+// (recordings, exports). It's stopped by Reset(), or by inputhandler.Exit() on errors.
+type outputCapture struct {
+	reader  *os.File
+	writer  *os.File
+	writers []io.WriteCloser
+	done    chan error
+}
+
+var activeCapture *outputCapture
+
+// startOutputCapture redirects os.Stdout through the capture writers.
+func startOutputCapture(writers []io.WriteCloser) error {
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("couldn't create pipe: %w", err)
+	}
+
+	activeCapture = &outputCapture{
+		reader:  reader,
+		writer:  writer,
+		writers: writers,
+		done:    make(chan error, 1),
+	}
+	os.Stdout = writer
+
+	go activeCapture.copy()
+
+	return nil
+}
+
+// stopOutputCapture waits until everything printed so far is copied,
+// then closes the capture writers and gives os.Stdout back to the terminal.
+func stopOutputCapture() error {
+
+	if activeCapture == nil {
+		return nil
+	}
+
+	os.Stdout = terminalOutput
+	activeCapture.writer.Close()
+	err := <-activeCapture.done
+	activeCapture.reader.Close()
+
+	for _, writer := range activeCapture.writers {
+		if closeErr := writer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	activeCapture = nil
+
+	return err
+}
+
+func (c *outputCapture) copy() {
+
+	var firstErr error
+	buf := make([]byte, 4096)
+	for {
+		n, err := c.reader.Read(buf)
+		if n > 0 {
+			terminalOutput.Write(buf[:n])
+			for _, writer := range c.writers {
+				if _, writeErr := writer.Write(buf[:n]); writeErr != nil && firstErr == nil {
+					firstErr = writeErr
+				}
+			}
+		}
+		if err != nil {
+			if err != io.EOF && firstErr == nil {
+				firstErr = err
+			}
+			break
+		}
+	}
+
+	c.done <- firstErr
+}
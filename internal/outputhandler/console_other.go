@@ -0,0 +1,35 @@
+//go:build !windows && !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package outputhandler
+
+import "fmt"
+
+var errorNoConsole = fmt.Errorf("console is not supported on this platform")
+
+func enableVirtualTerminalProcessing() error {
+	return nil
+}
+
+func restoreTerminalMode() {
+}
+
+// GetTerminalSize is not supported here.
+func GetTerminalSize() (int, int, error) {
+	return 0, 0, errorNoConsole
+}
+
+// EnableRawMode is not supported here.
+func EnableRawMode() error {
+	return errorNoConsole
+}
+
+func DisableRawMode() {
+}
+
+func isInputTerminal() bool {
+	return false
+}
+
+func isOutputTerminal() bool {
+	return false
+}
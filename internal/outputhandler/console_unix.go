@@ -0,0 +1,73 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package outputhandler
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+var origInputMode *unix.Termios
+
+// enableVirtualTerminalProcessing is a no-op, terminals here process CSI by default
+func enableVirtualTerminalProcessing() error {
+	return nil
+}
+
+func restoreTerminalMode() {
+}
+
+// GetTerminalSize returns the width and height of the terminal in characters.
+func GetTerminalSize() (int, int, error) {
+
+	winsize, err := unix.IoctlGetWinsize(int(terminalOutput.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error in TIOCGWINSZ ioctl: %w", err)
+	}
+
+	return int(winsize.Col), int(winsize.Row), nil
+}
+
+// EnableRawMode makes the keys readable one by one without echoing them.
+// Signals are left alone, so Ctrl-C still works.
+func EnableRawMode() error {
+
+	fd := int(os.Stdin.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return fmt.Errorf("error reading terminal attributes: %w", err)
+	}
+
+	rawMode := *termios
+	rawMode.Iflag &^= unix.ICRNL | unix.IXON
+	rawMode.Lflag &^= unix.ECHO | unix.ICANON
+	rawMode.Cc[unix.VMIN] = 1
+	rawMode.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlWriteTermios, &rawMode); err != nil {
+		return fmt.Errorf("error setting terminal attributes: %w", err)
+	}
+	origInputMode = termios
+
+	return nil
+}
+
+// DisableRawMode sets the input mode back how EnableRawMode() found it.
+func DisableRawMode() {
+	if origInputMode == nil {
+		return
+	}
+	unix.IoctlSetTermios(int(os.Stdin.Fd()), ioctlWriteTermios, origInputMode)
+	origInputMode = nil
+}
+
+func isInputTerminal() bool {
+	_, err := unix.IoctlGetTermios(int(os.Stdin.Fd()), ioctlReadTermios)
+	return err == nil
+}
+
+func isOutputTerminal() bool {
+	_, err := unix.IoctlGetTermios(int(terminalOutput.Fd()), ioctlReadTermios)
+	return err == nil
+}
@@ -0,0 +1,74 @@
+package outputhandler
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+var origTerminalMode uint32
+var origInputMode uint32
+var inputModeChanged bool
+
+// enableVirtualTerminalProcessing adds the flag to the current mode
+func enableVirtualTerminalProcessing() error {
+
+	fd := windows.Handle(terminalOutput.Fd())
+	if err := windows.GetConsoleMode(fd, &origTerminalMode); err != nil {
+		return err
+	}
+
+	return windows.SetConsoleMode(fd, origTerminalMode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+}
+
+func restoreTerminalMode() {
+	fd := windows.Handle(terminalOutput.Fd())
+	windows.SetConsoleMode(fd, origTerminalMode)
+}
+
+// GetTerminalSize returns the visible width and height of the console window in characters.
+func GetTerminalSize() (int, int, error) {
+
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(terminalOutput.Fd()), &info); err != nil {
+		return 0, 0, fmt.Errorf("error in GetConsoleScreenBufferInfo: %w", err)
+	}
+
+	width := int(info.Window.Right-info.Window.Left) + 1
+	height := int(info.Window.Bottom-info.Window.Top) + 1
+
+	return width, height, nil
+}
+
+// EnableRawMode makes the keys readable one by one without echoing them.
+// Ctrl-C still works. Arrow keys are sent as VT sequences.
+func EnableRawMode() error {
+
+	fd := windows.Handle(os.Stdin.Fd())
+	if err := windows.GetConsoleMode(fd, &origInputMode); err != nil {
+		return fmt.Errorf("error in GetConsoleMode: %w", err)
+	}
+
+	rawMode := origInputMode&^(windows.ENABLE_ECHO_INPUT|windows.ENABLE_LINE_INPUT) | windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	if err := windows.SetConsoleMode(fd, rawMode); err != nil {
+		return fmt.Errorf("error in SetConsoleMode: %w", err)
+	}
+	inputModeChanged = true
+
+	return nil
+}
+
+// DisableRawMode sets the input mode back how EnableRawMode() found it.
+func DisableRawMode() {
+	if !inputModeChanged {
+		return
+	}
+	windows.SetConsoleMode(windows.Handle(os.Stdin.Fd()), origInputMode)
+	inputModeChanged = false
+}
+
+func isInputTerminal() bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(os.Stdin.Fd()), &mode) == nil
+}
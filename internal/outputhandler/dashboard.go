@@ -0,0 +1,323 @@
+package outputhandler
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var dashboardPort = flag.Int("dashboard", 0, "show the output and the results in a browser on http://localhost:`port`")
+
+const dashboardFrameInterval = 100 * time.Millisecond
+
+// dashboardEvent is a Server-Sent Event, data is JSON encoded.
+type dashboardEvent struct {
+	name string
+	data string
+}
+
+// dashboard serves the output of the run as a web page. It gets the output the same
+// way as the recordings, interprets it on a virtual screen and pushes the screen
+// to the browsers as frames a few times a second. The results are pushed as they
+// are added.
+//
+// The server only listens on the loopback interface.
+type dashboard struct {
+	url    string
+	server *http.Server
+
+	mutex   sync.Mutex
+	screen  *htmlScreen
+	dirty   bool
+	latest  map[string]dashboardEvent // sent to the browsers connecting late
+	clients map[chan dashboardEvent]bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var activeDashboard *dashboard
+
+func startDashboard(port int) (*dashboard, error) {
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't listen on port %d: %w", port, err)
+	}
+
+	d := &dashboard{
+		url:     "http://" + listener.Addr().String() + "/",
+		screen:  newHTMLScreen(),
+		latest:  make(map[string]dashboardEvent),
+		clients: make(map[chan dashboardEvent]bool),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", d.servePage)
+	mux.HandleFunc("/events", d.serveEvents)
+	d.server = &http.Server{Handler: mux}
+
+	go d.server.Serve(listener)
+	go d.renderFrames()
+
+	activeDashboard = d
+
+	return d, nil
+}
+
+func (d *dashboard) Write(p []byte) (int, error) {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.screen.feed(p)
+	d.dirty = true
+
+	return len(p), nil
+}
+
+// Close stops the frames, the server keeps running until waitForDashboard() returns.
+func (d *dashboard) Close() error {
+	close(d.stop)
+	<-d.done
+	d.pushFrame()
+	return nil
+}
+
+func (d *dashboard) renderFrames() {
+
+	ticker := time.NewTicker(dashboardFrameInterval)
+	defer ticker.Stop()
+	defer close(d.done)
+
+	for {
+		select {
+		case <-ticker.C:
+			d.pushFrame()
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *dashboard) pushFrame() {
+
+	d.mutex.Lock()
+	if !d.dirty {
+		d.mutex.Unlock()
+		return
+	}
+	var sb strings.Builder
+	writeHTMLLines(&sb, d.screen.getLines())
+	d.dirty = false
+	d.mutex.Unlock()
+
+	d.broadcast("frame", sb.String())
+}
+
+func (d *dashboard) broadcast(name string, value interface{}) {
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	event := dashboardEvent{name: name, data: string(data)}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.latest[name] = event
+	for client := range d.clients {
+		// slow browsers miss frames, a newer one is on the way anyway
+		select {
+		case client <- event:
+		default:
+		}
+	}
+}
+
+func (d *dashboard) serveEvents(w http.ResponseWriter, r *http.Request) {
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	client := make(chan dashboardEvent, 64)
+	d.mutex.Lock()
+	for _, name := range []string{"frame", "results", "end"} {
+		if event, ok := d.latest[name]; ok {
+			client <- event
+		}
+	}
+	d.clients[client] = true
+	d.mutex.Unlock()
+
+	defer func() {
+		d.mutex.Lock()
+		delete(d.clients, client)
+		d.mutex.Unlock()
+	}()
+
+	for {
+		select {
+		case event := <-client:
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, event.data)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (d *dashboard) servePage(w http.ResponseWriter, r *http.Request) {
+
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, dashboardPage, html.EscapeString(filepath.Base(os.Args[0])), htmlPageStyle, CSSColor(Gray), CSSColor(BrightGreen), CSSColor(Gray))
+	buf.Flush()
+}
+
+//-Results---------------------------------------------------------------------
+
+type dashboardResult struct {
+	Part    string `json:"part"`
+	Answer  string `json:"answer"`
+	Elapsed string `json:"elapsed"`
+}
+
+type dashboardResults struct {
+	Day     int               `json:"day"`
+	Title   string            `json:"title"`
+	Results []dashboardResult `json:"results"`
+}
+
+// publishResults pushes the answers to the dashboard if it's running
+func publishResults(results *Results) {
+
+	if activeDashboard == nil {
+		return
+	}
+
+	value := dashboardResults{Day: results.Day, Title: results.Title, Results: make([]dashboardResult, 0, len(results.Results))}
+	for _, result := range results.Results {
+		value.Results = append(value.Results, dashboardResult{
+			Part:    result.Part,
+			Answer:  fmt.Sprint(result.Answer),
+			Elapsed: FormatElapsed(result.Elapsed),
+		})
+	}
+
+	activeDashboard.broadcast("results", value)
+}
+
+// waitForDashboard keeps the page alive after the run, until Enter or Ctrl-C is pressed
+func waitForDashboard() {
+
+	if activeDashboard == nil {
+		return
+	}
+
+	activeDashboard.broadcast("end", "finished")
+	fmt.Printf("The dashboard is still running on %s, press Enter or Ctrl-C to quit\n", activeDashboard.url)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt)
+	defer signal.Stop(quit)
+
+	enter := make(chan struct{})
+	go func() {
+		bufio.NewReader(os.Stdin).ReadString('\n')
+		close(enter)
+	}()
+
+	select {
+	case <-quit:
+	case <-enter:
+	}
+
+	activeDashboard.server.Close()
+	activeDashboard = nil
+}
+
+// dashboardPage is the page with the screen on the left and the results on the right.
+// Parameters: title, base style, status color, answer color, time color.
+const dashboardPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%[1]s</title>
+<style>
+%[2]s
+body { display: flex; gap: 2em; margin: 1em; font-family: Consolas, Menlo, "DejaVu Sans Mono", monospace; }
+#screen { flex: 1; overflow: auto; margin: 0; }
+#panel { min-width: 20em; }
+#status { color: %[3]s; }
+table { border-collapse: collapse; width: 100%%; }
+th, td { padding: 0.2em 0.6em; border-bottom: 1px solid #444; vertical-align: top; }
+th { text-align: left; }
+td.answer { color: %[4]s; text-align: right; white-space: pre; }
+td.time { color: %[5]s; text-align: right; }
+</style>
+</head>
+<body>
+<pre id="screen"></pre>
+<div id="panel">
+<h3 id="title">%[1]s</h3>
+<table><thead><tr><th>Part</th><th id="answers">Answer</th><th>Time</th></tr></thead><tbody id="results"></tbody></table>
+<p id="status">running...</p>
+</div>
+<script>
+const events = new EventSource("/events");
+events.addEventListener("frame", e => {
+	document.getElementById("screen").innerHTML = JSON.parse(e.data);
+});
+events.addEventListener("results", e => {
+	const results = JSON.parse(e.data);
+	document.getElementById("title").textContent = "Day " + String(results.day).padStart(2, "0");
+	document.getElementById("answers").textContent = results.title;
+	const body = document.getElementById("results");
+	body.innerHTML = "";
+	for (const result of results.results) {
+		const row = body.insertRow();
+		row.insertCell().textContent = result.part;
+		const answer = row.insertCell();
+		answer.className = "answer";
+		answer.textContent = result.answer;
+		const time = row.insertCell();
+		time.className = "time";
+		time.textContent = result.elapsed;
+	}
+});
+events.addEventListener("end", e => {
+	document.getElementById("status").textContent = JSON.parse(e.data);
+	events.close();
+});
+events.onerror = () => {
+	document.getElementById("status").textContent = "disconnected";
+};
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,173 @@
+package outputhandler
+
+import (
+	"flag"
+	"strings"
+)
+
+// Density is how many grid cells are drawn into a single character.
+type Density string
+
+const (
+	DensityNormal    Density = "normal"  // 1 cell per character
+	DensityHalfBlock Density = "half"    // 1x2 cells per character
+	DensityBraille   Density = "braille" // 2x4 cells per character
+)
+
+var densityFlag = flag.String("density", string(DensityNormal), "draw boolean grids with 'half' blocks or 'braille' dots")
+
+// GetRequestedDensity returns the density set on the commandline.
+func GetRequestedDensity() Density {
+	switch Density(*densityFlag) {
+	case DensityHalfBlock:
+		return DensityHalfBlock
+	case DensityBraille:
+		return DensityBraille
+	}
+	return DensityNormal
+}
+
+// GetCellsPerChar returns how many cells are drawn into a character horizontally and vertically.
+// Without Unicode support braille falls back to the half-block's ASCII version.
+func (d Density) GetCellsPerChar() (int, int) {
+	switch d {
+	case DensityHalfBlock:
+		return 1, 2
+	case DensityBraille:
+		if !CanUseEmojis() {
+			return 1, 2
+		}
+		return 2, 4
+	}
+	return 1, 1
+}
+
+// RenderGrid draws the grid with the given density. Set cells are '#', unset ones are '.'
+// for the normal density.
+func RenderGrid(grid [][]bool, density Density) []string {
+	switch density {
+	case DensityHalfBlock:
+		return RenderHalfBlocks(grid)
+	case DensityBraille:
+		return RenderBraille(grid)
+	}
+
+	lines := make([]string, len(grid))
+	for rowIdx, row := range grid {
+		var line strings.Builder
+		for _, cell := range row {
+			if cell {
+				line.WriteByte('#')
+			} else {
+				line.WriteByte('.')
+			}
+		}
+		lines[rowIdx] = line.String()
+	}
+	return lines
+}
+
+var halfBlocks = [4]rune{' ', '▀', '▄', '█'}
+var halfBlocksASCII = [4]rune{' ', '\'', '.', ':'}
+
+// RenderHalfBlocks draws two rows of the grid into one line.
+// Falls back to ASCII characters when there is no Unicode support.
+func RenderHalfBlocks(grid [][]bool) []string {
+
+	blocks := halfBlocks
+	if !CanUseEmojis() {
+		blocks = halfBlocksASCII
+	}
+
+	lines := make([]string, 0, (len(grid)+1)/2)
+	for rowIdx := 0; rowIdx < len(grid); rowIdx += 2 {
+
+		width := len(grid[rowIdx])
+		if rowIdx+1 < len(grid) && len(grid[rowIdx+1]) > width {
+			width = len(grid[rowIdx+1])
+		}
+
+		line := make([]rune, width)
+		for colIdx := range line {
+			var blockIdx int
+			if isGridCellSet(grid, colIdx, rowIdx) {
+				blockIdx |= 1
+			}
+			if isGridCellSet(grid, colIdx, rowIdx+1) {
+				blockIdx |= 2
+			}
+			line[colIdx] = blocks[blockIdx]
+		}
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
+
+// braille dot bits for the 2x4 cells by [row][col]
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+const brailleBase rune = 0x2800
+
+// RenderBraille draws 2x4 cells of the grid into one braille character.
+// Falls back to RenderHalfBlocks() when there is no Unicode support.
+func RenderBraille(grid [][]bool) []string {
+
+	if !CanUseEmojis() {
+		return RenderHalfBlocks(grid)
+	}
+
+	lines := make([]string, 0, (len(grid)+3)/4)
+	for rowIdx := 0; rowIdx < len(grid); rowIdx += 4 {
+
+		width := 0
+		for dotRow := 0; dotRow < 4 && rowIdx+dotRow < len(grid); dotRow++ {
+			if len(grid[rowIdx+dotRow]) > width {
+				width = len(grid[rowIdx+dotRow])
+			}
+		}
+
+		line := make([]rune, (width+1)/2)
+		for charIdx := range line {
+			char := brailleBase
+			for dotRow := 0; dotRow < 4; dotRow++ {
+				for dotCol := 0; dotCol < 2; dotCol++ {
+					if isGridCellSet(grid, charIdx*2+dotCol, rowIdx+dotRow) {
+						char |= brailleDots[dotRow][dotCol]
+					}
+				}
+			}
+			line[charIdx] = char
+		}
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
+
+// GridFromStrings creates a boolean grid from the lines, cells are set where the character is in setChars.
+func GridFromStrings(lines []string, setChars string) [][]bool {
+
+	grid := make([][]bool, len(lines))
+	for rowIdx, line := range lines {
+		runes := []rune(line)
+		grid[rowIdx] = make([]bool, len(runes))
+		for colIdx, char := range runes {
+			grid[rowIdx][colIdx] = strings.ContainsRune(setChars, char)
+		}
+	}
+
+	return grid
+}
+
+func isGridCellSet(grid [][]bool, x, y int) bool {
+	if y < 0 || y >= len(grid) || x < 0 || x >= len(grid[y]) {
+		return false
+	}
+	return grid[y][x]
+}
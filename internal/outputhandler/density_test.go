@@ -0,0 +1,55 @@
+package outputhandler
+
+import "testing"
+
+// a 5x5 ring with an uneven right edge, so the last character is only partly covered
+var testGridLines = []string{
+	"#####",
+	"#...#",
+	"#.#.#",
+	"#...#",
+	"####",
+}
+
+func TestRenderGrid(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		terminal TerminalInfo
+		density  Density
+	}{
+		{"grid_normal", plainTerminal, DensityNormal},
+		{"grid_half_unicode", unicodeTerminal, DensityHalfBlock},
+		{"grid_half_ascii", plainTerminal, DensityHalfBlock},
+		{"grid_braille_unicode", unicodeTerminal, DensityBraille},
+		{"grid_braille_ascii", plainTerminal, DensityBraille}, // falls back to the half blocks
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withTerminal(t, test.terminal)
+			checkGoldenLines(t, test.name, RenderGrid(GridFromStrings(testGridLines, "#"), test.density))
+		})
+	}
+}
+
+func TestGetCellsPerChar(t *testing.T) {
+
+	tests := []struct {
+		terminal     TerminalInfo
+		density      Density
+		wantX, wantY int
+	}{
+		{plainTerminal, DensityNormal, 1, 1},
+		{plainTerminal, DensityHalfBlock, 1, 2},
+		{plainTerminal, DensityBraille, 1, 2},
+		{unicodeTerminal, DensityBraille, 2, 4},
+	}
+
+	for _, test := range tests {
+		withTerminal(t, test.terminal)
+		if x, y := test.density.GetCellsPerChar(); x != test.wantX || y != test.wantY {
+			t.Errorf("%s on %s: got %dx%d, want %dx%d", test.density, test.terminal.Name, x, y, test.wantX, test.wantY)
+		}
+	}
+}
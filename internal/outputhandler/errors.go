@@ -0,0 +1,31 @@
+package outputhandler
+
+import (
+	"AoC22/internal/inputhandler"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PrintError prints the error after the context text, like "Error while parsing data".
+// Parse errors are shown with the line they were found in and a caret under the column.
+func PrintError(context string, err error) {
+
+	fmt.Printf("%s: %s%v%s\n", context, GetForeground(BrightRed), err, GetReset())
+
+	var parseErr *inputhandler.ParseError
+	if !errors.As(err, &parseErr) {
+		return
+	}
+
+	snippet := parseErr.Snippet()
+	if len(snippet) == 0 {
+		return
+	}
+
+	lines := strings.SplitN(snippet, "\n", 2)
+	fmt.Println(lines[0])
+	if len(lines) > 1 {
+		fmt.Println(GetForeground(BrightRed) + lines[1] + GetReset())
+	}
+}
@@ -0,0 +1,186 @@
+package outputhandler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExplorerGrid is a grid to inspect with ExploreGrid(). Depth above 1 makes it
+// a 3D grid that is shown one Z slice at a time.
+type ExplorerGrid struct {
+	Width, Height, Depth int
+
+	Cell  func(x, y, z int) rune   // the character of the cell
+	Value func(x, y, z int) string // shown for the cell under the cursor, optional
+	Empty rune                     // cells that are hidden by others when zoomed out, '.' by default
+}
+
+// gridExplorer is the state of the explorer between key presses
+type gridExplorer struct {
+	grid     *ExplorerGrid
+	viewport *Viewport
+	x, y, z  int // cursor
+	zoom     int // cells per character in both directions
+}
+
+const maxExplorerZoom = 64
+
+// ExploreGrid shows the grid and lets the user move the cursor around with the keys,
+// zoom out to see more of it and step through the Z slices of 3D grids until 'q' or
+// Esc is pressed. The cursor starts at the given cell.
+//
+// Raw mode is switched off before returning, Reset() does it too if we never get there.
+func ExploreGrid(grid *ExplorerGrid, startX, startY, startZ int) error {
+
+	if grid.Width <= 0 || grid.Height <= 0 {
+		return fmt.Errorf("nothing to explore in a %dx%d grid", grid.Width, grid.Height)
+	}
+	if grid.Depth <= 0 {
+		grid.Depth = 1
+	}
+	if grid.Empty == 0 {
+		grid.Empty = '.'
+	}
+
+	if err := EnableRawMode(); err != nil {
+		return fmt.Errorf("couldn't switch to raw mode: %w", err)
+	}
+	defer DisableRawMode()
+
+	explorer := &gridExplorer{
+		grid:     grid,
+		viewport: NewViewport(),
+		zoom:     1,
+	}
+	explorer.moveTo(startX, startY, startZ)
+
+	for {
+		explorer.printFrame()
+
+		key, err := ReadKey()
+		if err != nil {
+			return fmt.Errorf("couldn't read key: %w", err)
+		}
+
+		_, pageHeight := explorer.viewport.GetSize()
+		step := explorer.zoom
+		switch key {
+		case KeyUp, "k":
+			explorer.moveBy(0, -step, 0)
+		case KeyDown, "j":
+			explorer.moveBy(0, step, 0)
+		case KeyLeft, "h":
+			explorer.moveBy(-step, 0, 0)
+		case KeyRight, "l":
+			explorer.moveBy(step, 0, 0)
+		case KeyPageUp:
+			explorer.moveBy(0, -pageHeight*step, 0)
+		case KeyPageDown:
+			explorer.moveBy(0, pageHeight*step, 0)
+		case KeyHome:
+			explorer.moveTo(explorer.x, 0, explorer.z)
+		case KeyEnd:
+			explorer.moveTo(explorer.x, grid.Height-1, explorer.z)
+		case "[", "<":
+			explorer.moveBy(0, 0, -1)
+		case "]", ">":
+			explorer.moveBy(0, 0, 1)
+		case "-":
+			if explorer.zoom < maxExplorerZoom {
+				explorer.zoom *= 2
+			}
+		case "+", "=":
+			if explorer.zoom > 1 {
+				explorer.zoom /= 2
+			}
+		case KeyEscape, "q":
+			fmt.Println()
+			return nil
+		}
+	}
+}
+
+func (e *gridExplorer) moveBy(dx, dy, dz int) {
+	e.moveTo(e.x+dx, e.y+dy, e.z+dz)
+}
+
+func (e *gridExplorer) moveTo(x, y, z int) {
+	e.x = clampInt(x, 0, e.grid.Width-1)
+	e.y = clampInt(y, 0, e.grid.Height-1)
+	e.z = clampInt(z, 0, e.grid.Depth-1)
+}
+
+// getChar returns the character for a zoomed out block, the first non-empty cell of it
+func (e *gridExplorer) getChar(blockX, blockY int) rune {
+
+	for y := blockY * e.zoom; y < (blockY+1)*e.zoom && y < e.grid.Height; y++ {
+		for x := blockX * e.zoom; x < (blockX+1)*e.zoom && x < e.grid.Width; x++ {
+			if char := e.grid.Cell(x, y, e.z); char != e.grid.Empty {
+				return char
+			}
+		}
+	}
+
+	return e.grid.Empty
+}
+
+func (e *gridExplorer) printFrame() {
+
+	blocksX := (e.grid.Width + e.zoom - 1) / e.zoom
+	blocksY := (e.grid.Height + e.zoom - 1) / e.zoom
+	cursorX, cursorY := e.x/e.zoom, e.y/e.zoom
+
+	e.viewport.Follow(cursorX, cursorY)
+	e.viewport.clamp(blocksX, blocksY)
+	width, height := e.viewport.GetSize()
+
+	var sb strings.Builder
+	sb.WriteString(GetCursorHome() + GetClearScreen())
+	for blockY := e.viewport.Y; blockY < e.viewport.Y+height && blockY < blocksY; blockY++ {
+		for blockX := e.viewport.X; blockX < e.viewport.X+width && blockX < blocksX; blockX++ {
+
+			char := e.getChar(blockX, blockY)
+			if blockX == cursorX && blockY == cursorY {
+				if CanUseColors() {
+					sb.WriteString(GetColor(Black, BrightYellow) + string(char) + GetReset())
+				} else {
+					sb.WriteRune('@')
+				}
+				continue
+			}
+			sb.WriteRune(char)
+		}
+		sb.WriteString("\n")
+	}
+
+	status := fmt.Sprintf("x: %d/%d, y: %d/%d", e.x, e.grid.Width, e.y, e.grid.Height)
+	if e.grid.Depth > 1 {
+		status += fmt.Sprintf(", z: %d/%d", e.z, e.grid.Depth)
+	}
+	if e.zoom > 1 {
+		status += fmt.Sprintf(", zoom: 1:%d", e.zoom)
+	}
+	if e.grid.Value != nil {
+		status += ", value: " + e.grid.Value(e.x, e.y, e.z)
+	} else {
+		status += fmt.Sprintf(", value: '%c'", e.grid.Cell(e.x, e.y, e.z))
+	}
+
+	help := "arrows/hjkl: move, -/+: zoom, q: quit"
+	if e.grid.Depth > 1 {
+		help = "arrows/hjkl: move, [/]: slice, -/+: zoom, q: quit"
+	}
+
+	sb.WriteString(GetForeground(Gray) + status + " | " + help + GetReset())
+	fmt.Print(sb.String())
+}
+
+func clampInt(val, min, max int) int {
+	if val < min {
+		return min
+	}
+	if val > max {
+		return max
+	}
+	return val
+}
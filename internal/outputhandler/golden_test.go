@@ -0,0 +1,56 @@
+package outputhandler
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata with the current output")
+
+// checkGolden compares the output to 'testdata/<name>.golden' byte by byte.
+// After a deliberate change of the output, rewrite the files with:
+//
+//	go test ./internal/outputhandler -update
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("couldn't update the golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("couldn't read the golden file, create it with -update: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from '%s'\ngot:\n%q\nwant:\n%q", path, got, want)
+	}
+}
+
+// checkGoldenLines is checkGolden() for rendered lines, joined with '\n'.
+func checkGoldenLines(t *testing.T, name string, lines []string) {
+	t.Helper()
+	checkGolden(t, name, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// withTerminal makes the tests render for the terminal, the detected one is put back after the test.
+func withTerminal(t *testing.T, terminal TerminalInfo) {
+
+	detected := detectedTerminal
+	detectedTerminal = terminal
+	t.Cleanup(func() {
+		detectedTerminal = detected
+	})
+}
+
+// plainTerminal has no colors and no Unicode, unicodeTerminal has both
+var plainTerminal = TerminalInfo{Name: "plain"}
+var unicodeTerminal = TerminalInfo{Name: "unicode", CSICursorSupport: true, CSIColorSupport: true, EmojiSupport: true}
@@ -0,0 +1,270 @@
+package outputhandler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+)
+
+// GraphicsProtocol is the way images are sent to the terminal.
+type GraphicsProtocol string
+
+const (
+	GraphicsText  GraphicsProtocol = "text"  // no images, the visualizations are drawn with characters
+	GraphicsAuto  GraphicsProtocol = "auto"  // the best one the terminal is known to support
+	GraphicsSixel GraphicsProtocol = "sixel" // DEC sixels, supported by xterm, foot, mlterm, WezTerm, Windows Terminal...
+	GraphicsKitty GraphicsProtocol = "kitty" // kitty graphics protocol, supported by kitty, WezTerm, ghostty...
+)
+
+var graphicsProtocol = flag.String("graphics", string(GraphicsText), "draw grids as images: text, auto, sixel or kitty")
+
+// IsGraphicsRequested tells if the user asked for the visualizations as images.
+// The terminal may still not be able to show them, see PrintImage().
+func IsGraphicsRequested() bool {
+	return GraphicsProtocol(*graphicsProtocol) != GraphicsText
+}
+
+// GetGraphicsProtocol returns the protocol to use based on the command line and the
+// detected terminal. Text is returned if the output is not a terminal.
+func GetGraphicsProtocol() GraphicsProtocol {
+
+	if !CanUseCursorControl() {
+		return GraphicsText
+	}
+
+	switch GraphicsProtocol(*graphicsProtocol) {
+	case GraphicsAuto:
+		if CanUseKittyGraphics() {
+			return GraphicsKitty
+		}
+		if CanUseSixel() {
+			return GraphicsSixel
+		}
+	case GraphicsSixel:
+		return GraphicsSixel
+	case GraphicsKitty:
+		return GraphicsKitty
+	}
+
+	return GraphicsText
+}
+
+// PrintImage prints the image with the requested graphics protocol.
+// Returns false if images can't be used, so the caller can fall back to text.
+func PrintImage(img image.Image) bool {
+
+	switch GetGraphicsProtocol() {
+	case GraphicsSixel:
+		fmt.Println(EncodeSixel(img))
+		return true
+	case GraphicsKitty:
+		encoded, err := EncodeKitty(img)
+		if err != nil {
+			fmt.Printf("Warning: couldn't encode image: %v\n", err)
+			return false
+		}
+		fmt.Println(encoded)
+		return true
+	}
+
+	return false
+}
+
+// ImageColor returns the RGB equivalent of the terminal color.
+func ImageColor(color TerminalColor) color.NRGBA {
+	css := strings.TrimPrefix(CSSColor(color), "#")
+	value, _ := strconv.ParseUint(css, 16, 32)
+	return colorFromRGB(uint8(value>>16), uint8(value>>8), uint8(value))
+}
+
+// NewGridImage draws a grid with every cell as a scale x scale square.
+// Cells with nil color are left transparent.
+func NewGridImage(width, height, scale int, cellColor func(x, y int) color.Color) *image.NRGBA {
+
+	img := image.NewNRGBA(image.Rect(0, 0, width*scale, height*scale))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := cellColor(x, y)
+			if c == nil {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.Set(x*scale+dx, y*scale+dy, c)
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+func colorFromRGB(r, g, b uint8) color.NRGBA {
+	return color.NRGBA{R: r, G: g, B: b, A: 255}
+}
+
+//-Sixel-----------------------------------------------------------------------
+
+// EncodeSixel encodes the image as a DEC sixel sequence. Transparent pixels are
+// left as the terminal's background. Images with more than 256 colors are
+// reduced to a 6x6x6 color cube.
+//
+// The output only depends on the image, so it can be compared byte by byte.
+func EncodeSixel(img image.Image) string {
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	palette, pixels := sixelPalette(img, false)
+	if len(palette) > 256 {
+		palette, pixels = sixelPalette(img, true)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\033P0;1q")
+	fmt.Fprintf(&sb, "\"1;1;%d;%d", width, height)
+	for idx, c := range palette {
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", idx, sixelPercent(c.R), sixelPercent(c.G), sixelPercent(c.B))
+	}
+
+	band := make([]byte, width)
+	for bandTop := 0; bandTop < height; bandTop += 6 {
+
+		first := true
+		for colorIdx := range palette {
+
+			used := false
+			for x := 0; x < width; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && bandTop+dy < height; dy++ {
+					if pixels[(bandTop+dy)*width+x] == colorIdx {
+						bits |= 1 << dy
+					}
+				}
+				band[x] = byte('?' + bits)
+				used = used || bits != 0
+			}
+			if !used {
+				continue
+			}
+
+			if !first {
+				sb.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(&sb, "#%d", colorIdx)
+			writeSixelRuns(&sb, bytes.TrimRight(band, "?"))
+		}
+
+		sb.WriteByte('-')
+	}
+
+	sb.WriteString("\033\\")
+
+	return sb.String()
+}
+
+// sixelPalette collects the colors in the order of appearance and maps
+// every pixel to its palette index, -1 for transparent pixels.
+func sixelPalette(img image.Image, reduce bool) ([]color.NRGBA, []int) {
+
+	bounds := img.Bounds()
+	palette := make([]color.NRGBA, 0)
+	indexes := make(map[color.NRGBA]int)
+	pixels := make([]int, 0, bounds.Dx()*bounds.Dy())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A == 0 {
+				pixels = append(pixels, -1)
+				continue
+			}
+			c.A = 255
+			if reduce {
+				c = colorFromRGB(reduceColorLevel(c.R), reduceColorLevel(c.G), reduceColorLevel(c.B))
+			}
+
+			idx, ok := indexes[c]
+			if !ok {
+				idx = len(palette)
+				indexes[c] = idx
+				palette = append(palette, c)
+			}
+			pixels = append(pixels, idx)
+		}
+	}
+
+	return palette, pixels
+}
+
+// reduceColorLevel rounds the value to one of 6 levels
+func reduceColorLevel(value uint8) uint8 {
+	return uint8((int(value)*5 + 127) / 255 * 51)
+}
+
+func sixelPercent(value uint8) int {
+	return (int(value)*100 + 127) / 255
+}
+
+// writeSixelRuns writes the sixels with the repeated ones compressed
+func writeSixelRuns(sb *strings.Builder, sixels []byte) {
+
+	for start := 0; start < len(sixels); {
+		end := start + 1
+		for end < len(sixels) && sixels[end] == sixels[start] {
+			end++
+		}
+
+		if count := end - start; count > 3 {
+			fmt.Fprintf(sb, "!%d%c", count, sixels[start])
+		} else {
+			sb.Write(sixels[start:end])
+		}
+
+		start = end
+	}
+}
+
+//-Kitty-----------------------------------------------------------------------
+
+const kittyChunkSize = 4096
+
+// EncodeKitty encodes the image as PNG and wraps it into kitty graphics protocol
+// sequences. Responses from the terminal are suppressed.
+//
+// The output only depends on the image, so it can be compared byte by byte.
+func EncodeKitty(img image.Image) (string, error) {
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", fmt.Errorf("error encoding PNG: %w", err)
+	}
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var sb strings.Builder
+	for start := 0; start < len(data); start += kittyChunkSize {
+
+		end := start + kittyChunkSize
+		more := 1
+		if end >= len(data) {
+			end = len(data)
+			more = 0
+		}
+
+		if start == 0 {
+			fmt.Fprintf(&sb, "\033_Ga=T,f=100,q=2,m=%d;%s\033\\", more, data[start:end])
+		} else {
+			fmt.Fprintf(&sb, "\033_Gm=%d;%s\033\\", more, data[start:end])
+		}
+	}
+
+	return sb.String(), nil
+}
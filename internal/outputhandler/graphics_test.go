@@ -0,0 +1,153 @@
+package outputhandler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// newTestGridImage draws a 3x2 grid, 2x2 pixels per cell:
+//
+//	red   green  -
+//	blue  blue   red
+func newTestGridImage() *image.NRGBA {
+	cells := [][]color.Color{
+		{ImageColor(Red), ImageColor(Green), nil},
+		{ImageColor(Blue), ImageColor(Blue), ImageColor(Red)},
+	}
+	return NewGridImage(3, 2, 2, func(x, y int) color.Color {
+		return cells[y][x]
+	})
+}
+
+func TestEncodeSixel(t *testing.T) {
+	checkGolden(t, "grid.sixel", []byte(EncodeSixel(newTestGridImage())))
+}
+
+func TestEncodeSixelReducedColors(t *testing.T) {
+
+	// 300 colors don't fit into the 256 sixel registers, they're reduced to the 6x6x6 cube
+	img := NewGridImage(300, 1, 1, func(x, y int) color.Color {
+		return color.NRGBA{R: uint8(x), G: uint8(x / 2), B: uint8(x % 256), A: 255}
+	})
+
+	checkGolden(t, "reduced.sixel", []byte(EncodeSixel(img)))
+}
+
+func TestEncodeKitty(t *testing.T) {
+
+	encoded, err := EncodeKitty(newTestGridImage())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkGolden(t, "grid.kitty", []byte(encoded))
+}
+
+func TestEncodeKittyChunks(t *testing.T) {
+
+	// noise doesn't compress, so the PNG is split into several chunks
+	random := rand.New(rand.NewSource(1))
+	img := NewGridImage(64, 64, 1, func(x, y int) color.Color {
+		return color.NRGBA{R: uint8(random.Intn(256)), G: uint8(random.Intn(256)), B: uint8(random.Intn(256)), A: 255}
+	})
+
+	encoded, err := EncodeKitty(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := strings.Split(strings.TrimSuffix(encoded, "\033\\"), "\033\\")
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want more than one", len(chunks))
+	}
+
+	var data strings.Builder
+	for chunkIdx, chunk := range chunks {
+		control, payload, found := strings.Cut(strings.TrimPrefix(chunk, "\033_G"), ";")
+		if !found {
+			t.Fatalf("chunk %d has no payload: %q", chunkIdx, chunk)
+		}
+
+		wantControl := "m=1"
+		if chunkIdx == 0 {
+			wantControl = "a=T,f=100,q=2,m=1"
+		}
+		if chunkIdx == len(chunks)-1 {
+			wantControl = strings.TrimSuffix(wantControl, "1") + "0"
+		}
+		if control != wantControl {
+			t.Errorf("chunk %d: got control '%s', want '%s'", chunkIdx, control, wantControl)
+		}
+		if len(payload) > kittyChunkSize {
+			t.Errorf("chunk %d: got %d bytes of payload, the limit is %d", chunkIdx, len(payload), kittyChunkSize)
+		}
+		data.WriteString(payload)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data.String())
+	if err != nil {
+		t.Fatalf("couldn't decode the payload: %v", err)
+	}
+	decodedImg, err := png.Decode(bytes.NewReader(decoded))
+	if err != nil {
+		t.Fatalf("couldn't decode the PNG: %v", err)
+	}
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			if got, want := color.NRGBAModel.Convert(decodedImg.At(x, y)), img.At(x, y); got != want {
+				t.Fatalf("pixel %d,%d: got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestPrintImageTextFallback(t *testing.T) {
+
+	// without cursor control, like when the output is redirected, every protocol falls back to text
+	withTerminal(t, plainTerminal)
+	for _, protocol := range []GraphicsProtocol{GraphicsText, GraphicsAuto, GraphicsSixel, GraphicsKitty} {
+		withGraphicsProtocol(t, protocol)
+		if got := GetGraphicsProtocol(); got != GraphicsText {
+			t.Errorf("-graphics %s: got protocol '%s', want '%s'", protocol, got, GraphicsText)
+		}
+		if PrintImage(newTestGridImage()) {
+			t.Errorf("-graphics %s: PrintImage() printed an image without cursor control", protocol)
+		}
+	}
+}
+
+func TestGetGraphicsProtocolAuto(t *testing.T) {
+
+	tests := []struct {
+		terminal TerminalInfo
+		want     GraphicsProtocol
+	}{
+		{TerminalInfo{CSICursorSupport: true}, GraphicsText},
+		{TerminalInfo{CSICursorSupport: true, SixelSupport: true}, GraphicsSixel},
+		{TerminalInfo{CSICursorSupport: true, KittyGraphicsSupport: true}, GraphicsKitty},
+		{TerminalInfo{CSICursorSupport: true, SixelSupport: true, KittyGraphicsSupport: true}, GraphicsKitty},
+	}
+
+	withGraphicsProtocol(t, GraphicsAuto)
+	for _, test := range tests {
+		withTerminal(t, test.terminal)
+		if got := GetGraphicsProtocol(); got != test.want {
+			t.Errorf("%+v: got protocol '%s', want '%s'", test.terminal, got, test.want)
+		}
+	}
+}
+
+func withGraphicsProtocol(t *testing.T, protocol GraphicsProtocol) {
+
+	requested := *graphicsProtocol
+	*graphicsProtocol = string(protocol)
+	t.Cleanup(func() {
+		*graphicsProtocol = requested
+	})
+}
@@ -0,0 +1,304 @@
+package outputhandler
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var htmlFile = flag.String("html", "", "export the colored output to a self-contained HTML `file`")
+
+// htmlCell is a character on the virtual screen with its colors.
+// An empty background means the page's background.
+type htmlCell struct {
+	char       rune
+	foreground TerminalColor
+	background TerminalColor
+}
+
+// htmlExporter renders the output into a HTML page on Close().
+// The CSI sequences this package emits are interpreted on a virtual screen, so
+// redrawn frames and progress bars end up as the terminal showed them last.
+type htmlExporter struct {
+	path   string
+	screen *htmlScreen
+}
+
+func newHTMLExporter(path string) (*htmlExporter, error) {
+
+	// fail early rather than after a long run
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create file '%s': %w", path, err)
+	}
+	file.Close()
+
+	return &htmlExporter{path: path, screen: newHTMLScreen()}, nil
+}
+
+func (e *htmlExporter) Write(p []byte) (int, error) {
+	e.screen.feed(p)
+	return len(p), nil
+}
+
+func (e *htmlExporter) Close() error {
+
+	file, err := os.Create(e.path)
+	if err != nil {
+		return fmt.Errorf("couldn't create file '%s': %w", e.path, err)
+	}
+	defer file.Close()
+
+	buf := bufio.NewWriter(file)
+	writeHTMLPage(buf, filepath.Base(os.Args[0]), e.screen.getLines())
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("couldn't write file '%s': %w", e.path, err)
+	}
+
+	return nil
+}
+
+//-Virtual screen--------------------------------------------------------------
+
+type htmlScreen struct {
+	lines      [][]htmlCell
+	row, col   int
+	homeRow    int
+	foreground TerminalColor
+	background TerminalColor
+	pending    []byte // an escape sequence or UTF-8 sequence split between two writes
+}
+
+func newHTMLScreen() *htmlScreen {
+	return &htmlScreen{homeRow: -1, foreground: DefaultColor}
+}
+
+// feed interprets the text and the CSI sequences in it.
+// Unknown sequences are dropped.
+func (s *htmlScreen) feed(data []byte) {
+
+	data = append(s.pending, data...)
+	s.pending = nil
+
+	for idx := 0; idx < len(data); {
+
+		if data[idx] == '\033' {
+			end, complete := findEscapeEnd(data, idx)
+			if !complete {
+				s.pending = append([]byte{}, data[idx:]...)
+				return
+			}
+			if data[idx+1] == '[' {
+				s.applyCSI(string(data[idx+2:end]), rune(data[end]))
+			}
+			idx = end + 1
+			continue
+		}
+
+		if !utf8.FullRune(data[idx:]) {
+			s.pending = append([]byte{}, data[idx:]...)
+			return
+		}
+		char, size := utf8.DecodeRune(data[idx:])
+		idx += size
+
+		switch {
+		case char == '\n':
+			s.row++
+			s.col = 0
+		case char == '\r':
+			s.col = 0
+		case char == '\t':
+			s.put(' ')
+			for s.col%8 != 0 {
+				s.put(' ')
+			}
+		case char < ' ':
+			// other control characters don't print anything
+		default:
+			s.put(char)
+		}
+	}
+}
+
+// findEscapeEnd returns the index of the last byte of the escape sequence starting at idx.
+// CSI sequences end with a final byte, images and other strings with the string
+// terminator or BEL, the rest are two bytes long.
+func findEscapeEnd(data []byte, idx int) (int, bool) {
+
+	if idx+1 >= len(data) {
+		return 0, false
+	}
+
+	switch data[idx+1] {
+	case '[':
+		for end := idx + 2; end < len(data); end++ {
+			if data[end] >= 0x40 && data[end] <= 0x7e {
+				return end, true
+			}
+		}
+		return 0, false
+	case 'P', '_', ']':
+		for end := idx + 2; end < len(data); end++ {
+			if data[end] == '\a' || (data[end] == '\\' && data[end-1] == '\033') {
+				return end, true
+			}
+		}
+		return 0, false
+	}
+
+	return idx + 1, true
+}
+
+// getLines returns the screen without the trailing empty lines,
+// e.g. the newline printed by Reset()
+func (s *htmlScreen) getLines() [][]htmlCell {
+
+	lines := s.lines
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func (s *htmlScreen) put(char rune) {
+
+	for len(s.lines) <= s.row {
+		s.lines = append(s.lines, make([]htmlCell, 0))
+	}
+	for len(s.lines[s.row]) <= s.col {
+		s.lines[s.row] = append(s.lines[s.row], htmlCell{char: ' ', foreground: DefaultColor})
+	}
+
+	s.lines[s.row][s.col] = htmlCell{char: char, foreground: s.foreground, background: s.background}
+	s.col++
+}
+
+func (s *htmlScreen) applyCSI(params string, command rune) {
+
+	switch command {
+	case 'm':
+		s.applySGR(params)
+	case 'A':
+		lines, err := strconv.Atoi(params)
+		if err != nil {
+			lines = 1
+		}
+		s.row -= lines
+		if s.row < 0 {
+			s.row = 0
+		}
+	case 'H':
+		// the top of the screen is where the first frame started
+		if s.homeRow < 0 {
+			s.homeRow = s.row
+		}
+		s.row, s.col = s.homeRow, 0
+	case 'J':
+		if s.row < len(s.lines) {
+			s.truncateLine()
+			s.lines = s.lines[:s.row+1]
+		}
+	case 'K':
+		if s.row < len(s.lines) {
+			if params == "2" {
+				s.lines[s.row] = s.lines[s.row][:0]
+			} else {
+				s.truncateLine()
+			}
+		}
+	}
+}
+
+func (s *htmlScreen) truncateLine() {
+	if s.col < len(s.lines[s.row]) {
+		s.lines[s.row] = s.lines[s.row][:s.col]
+	}
+}
+
+func (s *htmlScreen) applySGR(params string) {
+
+	colors := make(map[int]TerminalColor, len(colorCodeBases))
+	for color, code := range colorCodeBases {
+		colors[code] = color
+	}
+
+	for _, param := range strings.Split(params, ";") {
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			code = 0
+		}
+		switch {
+		case code == 0:
+			s.foreground, s.background = DefaultColor, ""
+		case code == 49:
+			s.background = ""
+		case (code >= 40 && code <= 47) || (code >= 100 && code <= 107):
+			s.background = colors[code-10]
+		default:
+			if color, ok := colors[code]; ok {
+				s.foreground = color
+			}
+		}
+	}
+}
+
+//-Page------------------------------------------------------------------------
+
+func writeHTMLPage(buf *bufio.Writer, title string, lines [][]htmlCell) {
+
+	fmt.Fprintf(buf, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+	fmt.Fprintf(buf, "<style>\n%s</style>\n", htmlPageStyle)
+	buf.WriteString("</head>\n<body>\n<pre>")
+	writeHTMLLines(buf, lines)
+	buf.WriteString("</pre>\n</body>\n</html>\n")
+}
+
+var htmlPageStyle = fmt.Sprintf("body { background: %s; color: %s; }\npre { font-family: Consolas, Menlo, \"DejaVu Sans Mono\", monospace; line-height: 1.2; }\n", svgBackground, CSSColor(DefaultColor))
+
+// writeHTMLLines writes the lines as text with colored spans, to be put into a <pre>.
+func writeHTMLLines(w io.Writer, lines [][]htmlCell) {
+
+	for _, line := range lines {
+		for start := 0; start < len(line); {
+			end := start + 1
+			for end < len(line) && line[end].foreground == line[start].foreground && line[end].background == line[start].background {
+				end++
+			}
+
+			text := make([]rune, 0, end-start)
+			for _, cell := range line[start:end] {
+				text = append(text, cell.char)
+			}
+			writeHTMLSpan(w, line[start], string(text))
+
+			start = end
+		}
+		io.WriteString(w, "\n")
+	}
+}
+
+func writeHTMLSpan(w io.Writer, style htmlCell, text string) {
+
+	css := make([]string, 0, 2)
+	if style.foreground != DefaultColor {
+		css = append(css, "color: "+CSSColor(style.foreground))
+	}
+	if len(style.background) > 0 && style.background != DefaultColor {
+		css = append(css, "background: "+CSSColor(style.background))
+	}
+
+	if len(css) == 0 {
+		io.WriteString(w, html.EscapeString(text))
+		return
+	}
+	fmt.Fprintf(w, "<span style=\"%s\">%s</span>", strings.Join(css, "; "), html.EscapeString(text))
+}
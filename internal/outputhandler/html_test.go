@@ -0,0 +1,51 @@
+package outputhandler
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testScreenOutput is a run as the terminal gets it: a header, two frames of an
+// animation, a progress line redrawn in place, a line overwritten after going up,
+// and an image that's not shown.
+var testScreenOutput = "" +
+	"\033[93;49mDay 14\033[0m\n" +
+	"\033[H\033[J..#\n.o.\n" +
+	"\033[H\033[J\033[92;49m#\033[0m.#\n<o>\n" +
+	"checking 1/3\r\033[2Kchecking 3/3 \033[91;49m✗\033[0m\n" +
+	"first\nsecond\n\033[2Afirst, again\n\n" +
+	"\033_Ga=T,f=100;AAAA\033\\" +
+	"\033[96;41mtab\there\033[0m\n" +
+	"\033[0m\n"
+
+func renderTestScreen(writes [][]byte) []byte {
+
+	screen := newHTMLScreen()
+	for _, data := range writes {
+		screen.feed(data)
+	}
+
+	var buf bytes.Buffer
+	writeHTMLLines(&buf, screen.getLines())
+
+	return buf.Bytes()
+}
+
+func TestHTMLScreen(t *testing.T) {
+	checkGolden(t, "screen.html", renderTestScreen([][]byte{[]byte(testScreenOutput)}))
+}
+
+func TestHTMLScreenSplitWrites(t *testing.T) {
+
+	// the pipe can split the escape sequences and the runes anywhere
+	want := renderTestScreen([][]byte{[]byte(testScreenOutput)})
+
+	writes := make([][]byte, 0, len(testScreenOutput))
+	for idx := 0; idx < len(testScreenOutput); idx++ {
+		writes = append(writes, []byte{testScreenOutput[idx]})
+	}
+
+	if got := renderTestScreen(writes); !bytes.Equal(got, want) {
+		t.Errorf("byte by byte writes differ from one write\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package outputhandler
+
+import (
+	"os"
+)
+
+// Key is a key pressed in raw mode.
+// Printable keys are returned as is, like "q".
+type Key string
+
+const (
+	KeyUp       Key = "Up"
+	KeyDown     Key = "Down"
+	KeyLeft     Key = "Left"
+	KeyRight    Key = "Right"
+	KeyPageUp   Key = "PageUp"
+	KeyPageDown Key = "PageDown"
+	KeyHome     Key = "Home"
+	KeyEnd      Key = "End"
+	KeyEnter    Key = "Enter"
+	KeyEscape   Key = "Escape"
+	KeyUnknown  Key = "Unknown"
+)
+
+var escapeSequences = map[string]Key{
+	"\033[A":  KeyUp,
+	"\033[B":  KeyDown,
+	"\033[C":  KeyRight,
+	"\033[D":  KeyLeft,
+	"\033OA":  KeyUp,
+	"\033OB":  KeyDown,
+	"\033OC":  KeyRight,
+	"\033OD":  KeyLeft,
+	"\033[5~": KeyPageUp,
+	"\033[6~": KeyPageDown,
+	"\033[H":  KeyHome,
+	"\033[F":  KeyEnd,
+	"\033[1~": KeyHome,
+	"\033[4~": KeyEnd,
+}
+
+// ReadKey waits for a key press on stdin. Needs EnableRawMode() to return
+// without an Enter.
+//
+// NOTE: escape sequences are expected to arrive in one piece, which is
+// the case for keys pressed by hand.
+func ReadKey() (Key, error) {
+
+	buf := make([]byte, 16)
+	n, err := os.Stdin.Read(buf)
+	if err != nil {
+		return KeyUnknown, err
+	}
+	input := string(buf[:n])
+
+	switch input {
+	case "\033":
+		return KeyEscape, nil
+	case "\r", "\n", "\r\n":
+		return KeyEnter, nil
+	}
+
+	if key, ok := escapeSequences[input]; ok {
+		return key, nil
+	}
+	if input[0] == '\033' {
+		return KeyUnknown, nil
+	}
+
+	return Key(input[:1]), nil
+}
@@ -6,35 +6,37 @@
 package outputhandler
 
 import (
+	"AoC22/internal/inputhandler"
+	"flag"
 	"fmt"
-	"os"
-	"runtime"
+	"io"
 	"strconv"
-
-	"golang.org/x/sys/windows"
+	"strings"
+	"sync"
 )
 
 var detectedTerminal TerminalInfo
 var detectedEnvironment RunningEnvironment
 
 var terminalCommandProcessing = true
-var origTerminalMode uint32
+
+var interactiveMode = flag.Bool("interactive", false, "explore big visualizations with the keyboard")
 
 // Initialize sets up the output for color text
 func Initialize() {
 
-	if runtime.GOOS == "windows" {
-		// enable Virtual Terminal Processing by adding the flag to the current mode
-		fd := windows.Handle(os.Stdout.Fd())
-		if err := windows.GetConsoleMode(fd, &origTerminalMode); err != nil {
-			fmt.Printf("Warning: couldn't enable Virtual Terminal Processing: %v", err)
-			terminalCommandProcessing = false
-		} else {
-			if err = windows.SetConsoleMode(fd, origTerminalMode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
-				fmt.Printf("Warning: couldn't enable Virtual Terminal Processing: %v", err)
-				terminalCommandProcessing = false
-			}
-		}
+	// errors are reported by inputhandler.ReadInput()
+	_ = inputhandler.ParseFlags()
+
+	redirectHumanOutput()
+
+	if err := inputhandler.StartProfiling(); err != nil {
+		fmt.Printf("Warning: couldn't start profiling: %v\n", err)
+	}
+
+	if err := enableVirtualTerminalProcessing(); err != nil {
+		fmt.Printf("Warning: couldn't enable Virtual Terminal Processing: %v", err)
+		terminalCommandProcessing = false
 	}
 
 	terminal, env, err := GetTerminalInfo()
@@ -44,16 +46,105 @@ func Initialize() {
 		detectedTerminal = *terminal
 		detectedEnvironment = *env
 	}
+
+	startCaptures()
+	inputhandler.OnExit(finishOutput)
 }
 
 // Reset sets the terminal mode back how Initialize() found it
 func Reset() {
-	fmt.Println(GetReset())
-	if runtime.GOOS == "windows" {
-		// reset terminal mode
-		fd := windows.Handle(os.Stdout.Fd())
-		windows.SetConsoleMode(fd, origTerminalMode)
+
+	// read before finishOutput(), the Ctrl-C that quits the dashboard or a timeout
+	// while it waits don't change how the run ended
+	stopped, failed := inputhandler.IsStopped(), exampleFailed
+
+	finishOutput()
+
+	if stopped {
+		inputhandler.Exit(inputhandler.ErrorCodeStopped)
 	}
+	if failed {
+		inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+	}
+}
+
+var finishOutputOnce sync.Once
+
+// finishOutput saves the profiles and the captured output and restores the terminal.
+// It's called by Reset(), or by inputhandler.Exit() when the app exits on an error
+// and the deferred Reset() doesn't run.
+func finishOutput() {
+	finishOutputOnce.Do(func() {
+		DisableRawMode()
+		printAllocationSummary()
+		if err := inputhandler.StopProfiling(); err != nil {
+			fmt.Printf("Warning: couldn't save the profiles: %v\n", err)
+		}
+		if !inputhandler.IsGenerateRequested() {
+			// with -gen, stdout is the generated input and has to end with it
+			fmt.Println(GetReset())
+		}
+		if err := stopOutputCapture(); err != nil {
+			fmt.Printf("Warning: couldn't save the captured output: %v\n", err)
+		}
+		waitForDashboard()
+		restoreTerminalMode()
+	})
+}
+
+// startCaptures sets up the writers asked for on the command line
+func startCaptures() {
+
+	writers := make([]io.WriteCloser, 0)
+
+	if len(*recordFile) > 0 {
+		width, height, err := GetTerminalSize()
+		if err != nil {
+			width, height = 80, 24
+		}
+		cast, err := newAsciicastWriter(*recordFile, width, height)
+		if err != nil {
+			fmt.Printf("Warning: couldn't start recording: %v\n", err)
+		} else {
+			writers = append(writers, cast)
+		}
+	}
+
+	if len(*htmlFile) > 0 {
+		page, err := newHTMLExporter(*htmlFile)
+		if err != nil {
+			fmt.Printf("Warning: couldn't start HTML export: %v\n", err)
+		} else {
+			writers = append(writers, page)
+		}
+	}
+
+	if *dashboardPort > 0 {
+		dashboard, err := startDashboard(*dashboardPort)
+		if err != nil {
+			fmt.Printf("Warning: couldn't start the dashboard: %v\n", err)
+		} else {
+			fmt.Printf("Dashboard: %s\n", dashboard.url)
+			writers = append(writers, dashboard)
+		}
+	}
+
+	if len(writers) == 0 {
+		return
+	}
+
+	if err := startOutputCapture(writers); err != nil {
+		fmt.Printf("Warning: couldn't capture the output: %v\n", err)
+		for _, writer := range writers {
+			writer.Close()
+		}
+	}
+}
+
+// IsInteractive tells if the user asked for interactive visualizations
+// and there is a terminal to read the keys from.
+func IsInteractive() bool {
+	return *interactiveMode && isInputTerminal()
 }
 
 // TerminalColor is the actual terminal color values for bash
@@ -125,6 +216,19 @@ func CanUseEmojis() bool {
 	return detectedTerminal.EmojiSupport || detectedEnvironment.AddsEmojiSupport
 }
 
+// CanUseSixel can be used to determine if the terminal shows sixel images.
+// Although not even close to accurate. :)
+func CanUseSixel() bool {
+	return detectedTerminal.SixelSupport
+}
+
+// CanUseKittyGraphics can be used to determine if the terminal shows images sent
+// with the kitty graphics protocol.
+// Although not even close to accurate. :)
+func CanUseKittyGraphics() bool {
+	return detectedTerminal.KittyGraphicsSupport
+}
+
 // GetTerminalColor returns the format string for the requested foreground color.
 // Resets background to default color.
 // Note: some terminals may not make use of / correctly implement CSI.
@@ -164,3 +268,50 @@ func GetReset() string {
 	}
 	return "\033[0m"
 }
+
+// GetCursorHome returns the format string that moves the cursor to the top left corner.
+// Note: some terminals may not make use of / correctly implement CSI.
+func GetCursorHome() string {
+	if !CanUseCursorControl() {
+		return ""
+	}
+	return "\033[H"
+}
+
+// GetCursorUp returns the format string that moves the cursor up by the given lines.
+// Note: some terminals may not make use of / correctly implement CSI.
+func GetCursorUp(lines int) string {
+	if !CanUseCursorControl() || lines <= 0 {
+		return ""
+	}
+	return "\033[" + strconv.Itoa(lines) + "A"
+}
+
+// GetClearScreen returns the format string that clears the screen from the cursor down.
+// Note: some terminals may not make use of / correctly implement CSI.
+func GetClearScreen() string {
+	if !CanUseCursorControl() {
+		return ""
+	}
+	return "\033[J"
+}
+
+// GetClearLine returns the format string that clears the line the cursor is in.
+// The cursor is moved to the start of the line.
+// Note: some terminals may not make use of / correctly implement CSI.
+func GetClearLine() string {
+	if !CanUseCursorControl() {
+		return ""
+	}
+	return "\r\033[2K"
+}
+
+// ClearScreen clears the whole screen for a new frame. Without cursor control,
+// a separator line is printed instead, so the frames can still be told apart.
+func ClearScreen() {
+	if !CanUseCursorControl() {
+		fmt.Println(strings.Repeat("-", 80))
+		return
+	}
+	fmt.Print(GetCursorHome() + GetClearScreen())
+}
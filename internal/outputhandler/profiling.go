@@ -0,0 +1,46 @@
+package outputhandler
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+)
+
+const allocationSiteCount = 10
+
+// printAllocationSummary prints the top allocation sites when the memory profile was requested
+func printAllocationSummary() {
+
+	if !inputhandler.IsMemProfileRequested() {
+		return
+	}
+
+	table := NewTable("Top allocation sites",
+		TableColumn{Header: "Function"},
+		TableColumn{Header: "Location", Color: Gray},
+		TableColumn{Header: "Allocated", Align: AlignRight, Color: BrightMagenta},
+		TableColumn{Header: "Objects", Align: AlignRight},
+	)
+	for _, site := range inputhandler.GetTopAllocationSites(allocationSiteCount) {
+		table.AddRow(site.Function, fmt.Sprintf("%s:%d", site.File, site.Line), formatBytes(site.Bytes), site.Objects)
+	}
+
+	table.Print()
+}
+
+// formatBytes formats the size with binary prefixes, like 1.5 MiB
+func formatBytes(bytes int64) string {
+
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	value := float64(bytes) / unit
+	prefixIdx := 0
+	for value >= unit && prefixIdx < 4 {
+		value /= unit
+		prefixIdx++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", value, "KMGTP"[prefixIdx])
+}
@@ -0,0 +1,183 @@
+package outputhandler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// redraw limit for in-place rendering
+const progressDrawInterval = 100 * time.Millisecond
+
+// line printing limit when there is no cursor control
+const progressLogInterval = 5 * time.Second
+
+// ProgressBar shows how far a long running loop got, with the rate and ETA.
+// It's redrawn in place when cursor control is available, otherwise it
+// prints a line now and then.
+type ProgressBar struct {
+	Title string
+	Total int64
+	Width int // of the bar itself in characters
+
+	current  int64
+	start    time.Time
+	lastDraw time.Time
+}
+
+func NewProgressBar(title string, total int64) *ProgressBar {
+	now := time.Now()
+	return &ProgressBar{
+		Title:    title,
+		Total:    total,
+		Width:    30,
+		start:    now,
+		lastDraw: now,
+	}
+}
+
+// Update sets the current progress and draws it if it's time to.
+func (pb *ProgressBar) Update(current int64) {
+	pb.current = current
+	pb.draw(false)
+}
+
+// Increment adds one to the current progress.
+func (pb *ProgressBar) Increment() {
+	pb.Update(pb.current + 1)
+}
+
+// Finish draws the final state and moves to the next line.
+func (pb *ProgressBar) Finish() {
+	pb.draw(true)
+	if CanUseCursorControl() {
+		fmt.Println()
+	}
+}
+
+func (pb *ProgressBar) draw(force bool) {
+
+	now := time.Now()
+	if !force && !isTimeToDraw(now, pb.lastDraw) {
+		return
+	}
+	pb.lastDraw = now
+
+	var ratio float64
+	if pb.Total > 0 {
+		ratio = float64(pb.current) / float64(pb.Total)
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	elapsed := now.Sub(pb.start)
+	rate := float64(pb.current) / elapsed.Seconds()
+
+	eta := "?"
+	if rate > 0 {
+		eta = formatDuration(time.Duration(float64(pb.Total-pb.current) / rate * float64(time.Second)))
+	}
+
+	if !CanUseCursorControl() {
+		fmt.Printf("%s: %.2f%% (%s/s, ETA %s)\n", pb.Title, ratio*100, formatRate(rate), eta)
+		return
+	}
+
+	filled := int(ratio * float64(pb.Width))
+	bar := strings.Repeat("=", filled)
+	if filled < pb.Width {
+		bar += ">" + strings.Repeat(" ", pb.Width-filled-1)
+	}
+
+	fmt.Printf("%s%s [%s%s%s] %6.2f%% %s/s ETA %s", GetClearLine(), pb.Title, GetForeground(BrightGreen), bar, GetReset(), ratio*100, formatRate(rate), eta)
+}
+
+//-----------------------------------------------------------------------------
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+var spinnerFramesASCII = []string{"|", "/", "-", "\\"}
+
+// Spinner is the ProgressBar for loops without a known end. It shows the
+// elapsed time and a status text.
+type Spinner struct {
+	Title string
+
+	frameIdx int
+	start    time.Time
+	lastDraw time.Time
+}
+
+func NewSpinner(title string) *Spinner {
+	now := time.Now()
+	return &Spinner{
+		Title:    title,
+		start:    now,
+		lastDraw: now,
+	}
+}
+
+// Tick draws the next frame with the status if it's time to.
+func (s *Spinner) Tick(status string) {
+	s.draw(status, false)
+}
+
+// Finish draws the final status and moves to the next line.
+func (s *Spinner) Finish(status string) {
+	s.draw(status, true)
+	if CanUseCursorControl() {
+		fmt.Println()
+	}
+}
+
+func (s *Spinner) draw(status string, force bool) {
+
+	now := time.Now()
+	if !force && !isTimeToDraw(now, s.lastDraw) {
+		return
+	}
+	s.lastDraw = now
+
+	elapsed := formatDuration(now.Sub(s.start))
+
+	if !CanUseCursorControl() {
+		fmt.Printf("%s: %s (%s)\n", s.Title, status, elapsed)
+		return
+	}
+
+	frames := spinnerFrames
+	if !CanUseEmojis() {
+		frames = spinnerFramesASCII
+	}
+	s.frameIdx = (s.frameIdx + 1) % len(frames)
+
+	fmt.Printf("%s%s%s%s %s: %s (%s)", GetClearLine(), GetForeground(BrightGreen), frames[s.frameIdx], GetReset(), s.Title, status, elapsed)
+}
+
+//-----------------------------------------------------------------------------
+
+func isTimeToDraw(now time.Time, lastDraw time.Time) bool {
+	if CanUseCursorControl() {
+		return now.Sub(lastDraw) >= progressDrawInterval
+	}
+	return now.Sub(lastDraw) >= progressLogInterval
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return duration.Round(time.Millisecond).String()
+	}
+	return duration.Round(time.Second).String()
+}
+
+func formatRate(rate float64) string {
+	switch {
+	case rate >= 1e9:
+		return fmt.Sprintf("%.1fG", rate/1e9)
+	case rate >= 1e6:
+		return fmt.Sprintf("%.1fM", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%.1fk", rate/1e3)
+	}
+	return fmt.Sprintf("%.1f", rate)
+}
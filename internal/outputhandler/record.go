@@ -0,0 +1,181 @@
+package outputhandler
+
+import (
+	"AoC22/internal/inputhandler"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// OutputFormat is how the results of a run are printed.
+type OutputFormat string
+
+const (
+	OutputText OutputFormat = "text"
+	OutputJSON OutputFormat = "json"
+	OutputCSV  OutputFormat = "csv"
+)
+
+var outputFormatFlag = flag.String("output", string(OutputText), "print the results as 'text', or as a 'json' or 'csv' record for scripts")
+
+// recordOutput is where the machine-readable record goes. In json and csv mode
+// everything else printed goes to stderr, so stdout has the record only.
+var recordOutput io.Writer = os.Stdout
+
+// GetOutputFormat returns the output format set on the commandline.
+func GetOutputFormat() OutputFormat {
+	switch format := OutputFormat(*outputFormatFlag); format {
+	case OutputJSON, OutputCSV:
+		return format
+	}
+	return OutputText
+}
+
+// redirectHumanOutput sends the visualizations and the tables to stderr when
+// the results are printed for scripts.
+func redirectHumanOutput() {
+
+	if GetOutputFormat() == OutputText {
+		return
+	}
+
+	recordOutput = os.Stdout
+	os.Stdout = os.Stderr
+	terminalOutput = os.Stderr
+}
+
+// AnswerType is the kind of answer in a record.
+type AnswerType string
+
+const (
+	AnswerInt    AnswerType = "int"
+	AnswerString AnswerType = "string"
+	AnswerImage  AnswerType = "image" // multi-line text, like the day 10 CRT
+)
+
+// RunRecord is the machine-readable result of a run.
+type RunRecord struct {
+	Year     int          `json:"year"`
+	Day      int          `json:"day"`
+	Title    string       `json:"title"`
+	Input    string       `json:"input"`    // see inputhandler.GetInputSource()
+	Checksum string       `json:"checksum"` // see inputhandler.GetInputChecksum()
+	Results  []PartRecord `json:"results"`
+}
+
+// PartRecord is the answer of a part. The answer is a number, a string,
+// or the lines of an image.
+//
+// NOTE: Decode records with json.Decoder.UseNumber(), some answers don't fit into a float64.
+type PartRecord struct {
+	Part      string      `json:"part"`
+	Type      AnswerType  `json:"type"`
+	Answer    interface{} `json:"answer"`
+	ElapsedNs int64       `json:"elapsed_ns"`
+	Stopped   string      `json:"stopped,omitempty"`  // why the part was stopped, the answer is the progress then
+	Expected  string      `json:"expected,omitempty"` // the answer of the worked example, when that was solved
+}
+
+// AnswerText returns the answer as text, image lines joined with '\n'.
+// Works on decoded records too.
+func (p PartRecord) AnswerText() string {
+
+	switch answer := p.Answer.(type) {
+	case []string:
+		return strings.Join(answer, "\n")
+	case []interface{}:
+		lines := make([]string, 0, len(answer))
+		for _, line := range answer {
+			lines = append(lines, fmt.Sprint(line))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	return fmt.Sprint(p.Answer)
+}
+
+// Record returns the results collected so far as a record.
+func (r *Results) Record() RunRecord {
+
+	record := RunRecord{
+		Year:     r.Year,
+		Day:      r.Day,
+		Title:    r.Title,
+		Input:    inputhandler.GetInputSource(),
+		Checksum: inputhandler.GetInputChecksum(),
+		Results:  make([]PartRecord, 0, len(r.Results)),
+	}
+
+	for _, result := range r.Results {
+		answerType, answer := getTypedAnswer(result.Answer)
+		part := PartRecord{
+			Part:      result.Part,
+			Type:      answerType,
+			Answer:    answer,
+			ElapsedNs: result.Elapsed.Nanoseconds(),
+		}
+		if expected, ok := inputhandler.GetExpectedAnswer(result.Part); ok {
+			part.Expected = expected
+		}
+		if result.Stopped {
+			part.Type = AnswerString
+			part.Answer = fmt.Sprint(result.Answer)
+			part.Stopped = inputhandler.GetStopReason()
+		}
+		record.Results = append(record.Results, part)
+	}
+
+	return record
+}
+
+func getTypedAnswer(answer interface{}) (AnswerType, interface{}) {
+
+	switch reflect.ValueOf(answer).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return AnswerInt, answer
+	}
+
+	text := fmt.Sprint(answer)
+	if strings.Contains(text, "\n") {
+		return AnswerImage, strings.Split(text, "\n")
+	}
+
+	return AnswerString, text
+}
+
+// printRecord prints the record in the requested format
+func printRecord(record RunRecord) error {
+
+	switch GetOutputFormat() {
+	case OutputJSON:
+		return json.NewEncoder(recordOutput).Encode(record)
+
+	case OutputCSV:
+		writer := csv.NewWriter(recordOutput)
+		writer.Write([]string{"year", "day", "part", "type", "answer", "elapsed_ns", "stopped", "input", "checksum"})
+		for _, part := range record.Results {
+			writer.Write([]string{
+				strconv.Itoa(record.Year),
+				strconv.Itoa(record.Day),
+				part.Part,
+				string(part.Type),
+				part.AnswerText(),
+				strconv.FormatInt(part.ElapsedNs, 10),
+				part.Stopped,
+				record.Input,
+				record.Checksum,
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return nil
+}
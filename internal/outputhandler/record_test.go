@@ -0,0 +1,86 @@
+package outputhandler
+
+import (
+	"bytes"
+	"testing"
+)
+
+// newTestRecord has an int answer too big for a float64, a string, an image with
+// a comma for the CSV quoting, and a stopped part
+func newTestRecord() RunRecord {
+
+	record := RunRecord{Year: 2022, Day: 10, Title: "Result", Input: "input.txt", Checksum: "abc"}
+	for idx, answer := range []interface{}{int64(9007199254740993), "CMZ", "##..\n..,#"} {
+		answerType, typed := getTypedAnswer(answer)
+		record.Results = append(record.Results, PartRecord{
+			Part:      []string{"Part1", "Part2", "Part3"}[idx],
+			Type:      answerType,
+			Answer:    typed,
+			ElapsedNs: int64(1000 * (idx + 1)),
+		})
+	}
+	record.Results = append(record.Results, PartRecord{Part: "Part4", Type: AnswerString, Answer: "2 of 20 rounds", ElapsedNs: 4000, Stopped: "interrupted"})
+	record.Results[0].Expected = "9007199254740993"
+
+	return record
+}
+
+func TestPrintRecord(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		format OutputFormat
+	}{
+		{"record.json", OutputJSON},
+		{"record.csv", OutputCSV},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			format, output := *outputFormatFlag, recordOutput
+			defer func() { *outputFormatFlag, recordOutput = format, output }()
+
+			var buffer bytes.Buffer
+			*outputFormatFlag, recordOutput = string(test.format), &buffer
+			if err := printRecord(newTestRecord()); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, test.name, buffer.Bytes())
+		})
+	}
+}
+
+func TestPrintRecordText(t *testing.T) {
+
+	output := recordOutput
+	defer func() { recordOutput = output }()
+
+	var buffer bytes.Buffer
+	recordOutput = &buffer
+	if err := printRecord(newTestRecord()); err != nil || buffer.Len() > 0 {
+		t.Errorf("got %q, %v, want no record in text mode", buffer.String(), err)
+	}
+}
+
+func TestGetTypedAnswer(t *testing.T) {
+
+	tests := []struct {
+		answer   interface{}
+		wantType AnswerType
+		wantText string
+	}{
+		{42, AnswerInt, "42"},
+		{uint8(7), AnswerInt, "7"},
+		{"CMZ", AnswerString, "CMZ"},
+		{"#.\n.#", AnswerImage, "#.\n.#"},
+		{1.5, AnswerString, "1.5"},
+	}
+
+	for _, test := range tests {
+		answerType, answer := getTypedAnswer(test.answer)
+		text := PartRecord{Answer: answer}.AnswerText()
+		if answerType != test.wantType || text != test.wantText {
+			t.Errorf("%v: got %s %q, want %s %q", test.answer, answerType, text, test.wantType, test.wantText)
+		}
+	}
+}
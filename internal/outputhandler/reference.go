@@ -0,0 +1,50 @@
+package outputhandler
+
+import (
+	"AoC22/internal/inputhandler"
+	"errors"
+	"fmt"
+)
+
+// SolveReference solves the requested parts with the day's reference solver when -reference
+// is given, see inputhandler.SetReference(). Returns false if the day should solve them itself.
+//
+//	if results.SolveReference(lines) {
+//		results.Print()
+//		return
+//	}
+func (r *Results) SolveReference(lines []string) bool {
+
+	if !inputhandler.IsReferenceRequested() {
+		return false
+	}
+
+	solve := inputhandler.GetReference()
+	if solve == nil {
+		PrintError("Error", inputhandler.ErrorNoReference)
+		inputhandler.Exit(inputhandler.ErrorCodeParameters)
+	}
+
+	ctx := inputhandler.GetContext()
+	for part := 1; part <= 2; part++ {
+		if !inputhandler.IsPartRequested(part) {
+			continue
+		}
+		partName := fmt.Sprintf("Part%d", part)
+
+		answer, err := solve(ctx, lines, part)
+		switch {
+		case errors.Is(err, inputhandler.ErrorNoReference):
+			// only the other part has one
+		case inputhandler.IsStopError(err):
+			r.AddStopped(partName, "reference solver stopped")
+		case err != nil:
+			PrintError(fmt.Sprintf("Error in the reference solver of part %d", part), err)
+			inputhandler.Exit(inputhandler.ErrorCodeProcessing)
+		default:
+			r.Add(partName, answer)
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,126 @@
+package outputhandler
+
+import (
+	"AoC22/internal/inputhandler"
+	"fmt"
+	"time"
+)
+
+// Result is the answer for a part of a day's puzzle.
+type Result struct {
+	Part    string
+	Answer  interface{}
+	Elapsed time.Duration // since the previous answer, or since the collector was created
+	Stopped bool          // by a timeout or Ctrl-C, the answer is the progress made
+}
+
+// Results collects the answers of a day and prints them as a table.
+type Results struct {
+	Year    int
+	Day     int
+	Title   string
+	Results []Result
+
+	lastAdd time.Time
+}
+
+// NewResults creates the collector, title is what the answers are, like "MaxCalories".
+// Create it right before the solving starts, the time of the first part is measured from here.
+func NewResults(year, day int, title string) *Results {
+	return &Results{
+		Year:    year,
+		Day:     day,
+		Title:   title,
+		Results: make([]Result, 0, 2),
+		lastAdd: time.Now(),
+	}
+}
+
+// Add adds the answer of a part. Multi-line answers are fine.
+func (r *Results) Add(part string, answer interface{}) {
+
+	r.Results = append(r.Results, Result{Part: part, Answer: answer, Elapsed: time.Since(r.lastAdd)})
+
+	publishResults(r)
+
+	// publishing is not part of the next part's time
+	r.lastAdd = time.Now()
+}
+
+// AddStopped adds the part that was stopped by a timeout or Ctrl-C,
+// with the progress it made, like "2000 of 4000000 rows".
+func (r *Results) AddStopped(part string, progress string) {
+
+	inputhandler.SetStopped()
+	r.Results = append(r.Results, Result{Part: part, Answer: progress, Elapsed: time.Since(r.lastAdd), Stopped: true})
+
+	publishResults(r)
+
+	r.lastAdd = time.Now()
+}
+
+// Print prints the collected answers as a table, and as a record if
+// the json or csv output was requested.
+func (r *Results) Print() {
+
+	if err := printRecord(r.Record()); err != nil {
+		fmt.Printf("Warning: couldn't print the results record: %v\n", err)
+	}
+
+	table := NewTable(fmt.Sprintf("Day %02d", r.Day),
+		TableColumn{Header: "Part"},
+		TableColumn{Header: r.Title, Align: AlignRight, Color: BrightGreen},
+		TableColumn{Header: "Time", Align: AlignRight, Color: Gray},
+	)
+	if inputhandler.IsExampleRequested() {
+		table.Columns = append(table.Columns, TableColumn{Header: "Example", Align: AlignRight, Color: BrightYellow})
+	}
+
+	mismatches := make([]error, 0)
+	for _, result := range r.Results {
+		answer := result.Answer
+		if result.Stopped {
+			answer = fmt.Sprintf("%s: %v", inputhandler.GetStopReason(), answer)
+		}
+
+		expected, ok := inputhandler.GetExpectedAnswer(result.Part)
+		switch {
+		case !ok || result.Stopped:
+			expected = ""
+		case fmt.Sprint(result.Answer) == expected:
+			expected = getExampleMatchMark()
+		default:
+			mismatches = append(mismatches, fmt.Errorf("the answer of '%s' should be '%s' for the example", result.Part, expected))
+		}
+
+		table.AddRow(result.Part, answer, FormatElapsed(result.Elapsed), expected)
+	}
+
+	table.Print()
+
+	for _, err := range mismatches {
+		PrintError("Error", err)
+		exampleFailed = true
+	}
+}
+
+// set when an answer doesn't match the example's, Reset() exits with an error then
+var exampleFailed bool
+
+func getExampleMatchMark() string {
+	if CanUseEmojis() {
+		return "✓"
+	}
+	return "ok"
+}
+
+// FormatElapsed rounds the time to 3-4 significant digits, like 1.23ms
+func FormatElapsed(elapsed time.Duration) string {
+	switch {
+	case elapsed < time.Millisecond:
+		return elapsed.Round(time.Microsecond).String()
+	case elapsed < time.Second:
+		return elapsed.Round(10 * time.Microsecond).String()
+	}
+	return elapsed.Round(time.Millisecond).String()
+}
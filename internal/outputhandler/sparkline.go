@@ -0,0 +1,36 @@
+package outputhandler
+
+// Sparkline draws the values as a row of bars, one character each, scaled between
+// the smallest and the biggest value. Like "▁▃▂▇" for the run times of a day.
+func Sparkline(values []float64) string {
+
+	levels := []rune("▁▂▃▄▅▆▇█")
+	if !CanUseEmojis() {
+		levels = []rune("_.:-=+*#")
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := values[0], values[0]
+	for _, value := range values {
+		if value < min {
+			min = value
+		}
+		if value > max {
+			max = value
+		}
+	}
+
+	bars := make([]rune, len(values))
+	for idx, value := range values {
+		level := 0
+		if max > min {
+			level = int((value - min) / (max - min) * float64(len(levels)-1))
+		}
+		bars[idx] = levels[level]
+	}
+
+	return string(bars)
+}
@@ -0,0 +1,274 @@
+package outputhandler
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+)
+
+// cssColors are the browser equivalents of the terminal colors.
+// Values are roughly what VS Code's default dark theme uses.
+var cssColors = map[TerminalColor]string{
+	DefaultColor:  "#cccccc",
+	Red:           "#cd3131",
+	Green:         "#0dbc79",
+	Yellow:        "#e5e510",
+	Blue:          "#2472c8",
+	Magenta:       "#bc3fbc",
+	Cyan:          "#11a8cd",
+	BrightRed:     "#f14c4c",
+	BrightGreen:   "#23d18b",
+	BrightYellow:  "#f5f543",
+	BrightBlue:    "#3b8eea",
+	BrightMagenta: "#d670d6",
+	BrightCyan:    "#29b8db",
+	White:         "#e5e5e5",
+	Gray:          "#a0a0a0",
+	DarkGray:      "#666666",
+	Black:         "#000000",
+}
+
+// CSSColor returns the CSS color value for the terminal color.
+func CSSColor(color TerminalColor) string {
+	if css, ok := cssColors[color]; ok {
+		return css
+	}
+	return cssColors[DefaultColor]
+}
+
+var svgFile = flag.String("svg", "", "export the day's picture to an SVG `file` (day 7, day 9 and day 12)")
+
+// GetSVGPath returns the file to export the day's picture to, empty if -svg is not given.
+func GetSVGPath() string {
+	return *svgFile
+}
+
+const svgBackground = "#1e1e1e"
+const svgFont = "font-family=\"monospace\""
+
+// WriteSVGFile writes the SVG image to a standalone file that can be opened in a browser.
+func WriteSVGFile(path string, svg io.WriterTo) error {
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("couldn't create file '%s': %w", path, err)
+	}
+
+	_, err = svg.WriteTo(file)
+	if err != nil {
+		err = fmt.Errorf("couldn't write file '%s': %w", path, err)
+	}
+	if closeErr := file.Close(); closeErr != nil && err == nil {
+		err = fmt.Errorf("couldn't close file '%s': %w", path, closeErr)
+	}
+
+	return err
+}
+
+//-Grid------------------------------------------------------------------------
+
+// SVGPoint is a cell coordinate on an SVGGrid.
+type SVGPoint struct {
+	X, Y int
+}
+
+// SVGPolyline is a line going through the centers of the given cells.
+type SVGPolyline struct {
+	Points []SVGPoint
+	Color  string // CSS color
+	Label  string // shown as a tooltip in browsers
+}
+
+// SVGGrid renders a grid of cells with polylines (paths, tracks) drawn over it.
+// Cell funcs are optional, cells without color or text are left as background.
+type SVGGrid struct {
+	Width, Height int
+	CellSize      int
+
+	CellColor func(x, y int) string // CSS color, "" for none
+	CellText  func(x, y int) string // a short text, "" for none
+
+	Polylines []SVGPolyline
+}
+
+func NewSVGGrid(width, height int) *SVGGrid {
+	return &SVGGrid{
+		Width:     width,
+		Height:    height,
+		CellSize:  12,
+		Polylines: make([]SVGPolyline, 0),
+	}
+}
+
+func (g *SVGGrid) AddPolyline(points []SVGPoint, color string, label string) {
+	g.Polylines = append(g.Polylines, SVGPolyline{Points: points, Color: color, Label: label})
+}
+
+// WriteTo writes the whole SVG document to w.
+func (g *SVGGrid) WriteTo(w io.Writer) (int64, error) {
+
+	var buf bytes.Buffer
+	cs := g.CellSize
+
+	writeSVGHeader(&buf, g.Width*cs, g.Height*cs)
+
+	if g.CellColor != nil {
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				color := g.CellColor(x, y)
+				if len(color) == 0 {
+					continue
+				}
+				fmt.Fprintf(&buf, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", x*cs, y*cs, cs, cs, html.EscapeString(color))
+			}
+		}
+	}
+
+	if g.CellText != nil {
+		fmt.Fprintf(&buf, "<g %s font-size=\"%d\" fill=\"%s\" text-anchor=\"middle\" dominant-baseline=\"central\">\n", svgFont, cs*3/4, CSSColor(White))
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				text := g.CellText(x, y)
+				if len(text) == 0 {
+					continue
+				}
+				fmt.Fprintf(&buf, "<text x=\"%d\" y=\"%d\">%s</text>\n", x*cs+cs/2, y*cs+cs/2, html.EscapeString(text))
+			}
+		}
+		buf.WriteString("</g>\n")
+	}
+
+	for _, line := range g.Polylines {
+
+		points := make([]string, len(line.Points))
+		for idx, point := range line.Points {
+			points[idx] = fmt.Sprintf("%d,%d", point.X*cs+cs/2, point.Y*cs+cs/2)
+		}
+
+		fmt.Fprintf(&buf, "<polyline points=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"%d\" stroke-linejoin=\"round\" stroke-linecap=\"round\">", strings.Join(points, " "), html.EscapeString(line.Color), cs/3+1)
+		if len(line.Label) > 0 {
+			fmt.Fprintf(&buf, "<title>%s</title>", html.EscapeString(line.Label))
+		}
+		buf.WriteString("</polyline>\n")
+	}
+
+	buf.WriteString("</svg>\n")
+
+	return buf.WriteTo(w)
+}
+
+//-Tree------------------------------------------------------------------------
+
+// SVGTreeNode is a node of the hierarchy rendered by SVGTree.
+type SVGTreeNode struct {
+	Label    string
+	Size     int
+	Color    string // CSS color of the label
+	Children []*SVGTreeNode
+}
+
+// SVGTree renders a hierarchy as an indented tree with the sizes shown
+// as bars relative to the root's size.
+type SVGTree struct {
+	Root       *SVGTreeNode
+	RowHeight  int
+	IndentSize int
+	BarWidth   int
+}
+
+func NewSVGTree(root *SVGTreeNode) *SVGTree {
+	return &SVGTree{
+		Root:       root,
+		RowHeight:  18,
+		IndentSize: 20,
+		BarWidth:   200,
+	}
+}
+
+type svgTreeRow struct {
+	node  *SVGTreeNode
+	depth int
+}
+
+// WriteTo writes the whole SVG document to w.
+func (t *SVGTree) WriteTo(w io.Writer) (int64, error) {
+
+	rows := t.flatten(t.Root, 0, make([]svgTreeRow, 0))
+
+	maxLabelEnd := 0
+	for _, row := range rows {
+		labelEnd := row.depth*t.IndentSize + (len(row.node.Label)+12)*t.RowHeight/2
+		if labelEnd > maxLabelEnd {
+			maxLabelEnd = labelEnd
+		}
+	}
+	barLeft := maxLabelEnd + t.IndentSize
+
+	var buf bytes.Buffer
+	writeSVGHeader(&buf, barLeft+t.BarWidth+t.IndentSize, (len(rows)+1)*t.RowHeight)
+
+	// connectors: a vertical line from each parent to its last child, and elbows
+	fmt.Fprintf(&buf, "<g stroke=\"%s\" stroke-width=\"1\">\n", CSSColor(Gray))
+	for rowIdx, row := range rows {
+		if row.depth == 0 {
+			continue
+		}
+		x := (row.depth-1)*t.IndentSize + t.IndentSize/2
+		y := rowIdx*t.RowHeight + t.RowHeight
+		fmt.Fprintf(&buf, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\"/>\n", x, y, x+t.IndentSize/2, y)
+
+		// up to the parent row, overlapping the siblings' lines
+		for parentIdx := rowIdx - 1; parentIdx >= 0; parentIdx-- {
+			if rows[parentIdx].depth < row.depth {
+				fmt.Fprintf(&buf, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\"/>\n", x, parentIdx*t.RowHeight+t.RowHeight+t.RowHeight/3, x, y)
+				break
+			}
+		}
+	}
+	buf.WriteString("</g>\n")
+
+	fmt.Fprintf(&buf, "<g %s font-size=\"%d\" dominant-baseline=\"central\">\n", svgFont, t.RowHeight*2/3)
+	for rowIdx, row := range rows {
+
+		x := row.depth * t.IndentSize
+		y := rowIdx*t.RowHeight + t.RowHeight
+
+		color := row.node.Color
+		if len(color) == 0 {
+			color = CSSColor(DefaultColor)
+		}
+		fmt.Fprintf(&buf, "<text x=\"%d\" y=\"%d\" fill=\"%s\">%s", x+2, y, html.EscapeString(color), html.EscapeString(row.node.Label))
+		fmt.Fprintf(&buf, "<tspan fill=\"%s\"> %d</tspan></text>\n", CSSColor(BrightMagenta), row.node.Size)
+
+		if t.Root.Size > 0 {
+			barLength := row.node.Size * t.BarWidth / t.Root.Size
+			fmt.Fprintf(&buf, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", barLeft, y-t.RowHeight/4, barLength, t.RowHeight/2, CSSColor(BrightMagenta))
+		}
+	}
+	buf.WriteString("</g>\n")
+
+	buf.WriteString("</svg>\n")
+
+	return buf.WriteTo(w)
+}
+
+func (t *SVGTree) flatten(node *SVGTreeNode, depth int, rows []svgTreeRow) []svgTreeRow {
+
+	rows = append(rows, svgTreeRow{node: node, depth: depth})
+	for _, child := range node.Children {
+		rows = t.flatten(child, depth+1, rows)
+	}
+
+	return rows
+}
+
+//-----------------------------------------------------------------------------
+
+func writeSVGHeader(buf *bytes.Buffer, width, height int) {
+	fmt.Fprintf(buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", width, height, width, height)
+	fmt.Fprintf(buf, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", svgBackground)
+}
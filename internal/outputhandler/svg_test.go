@@ -0,0 +1,96 @@
+package outputhandler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestSVGGrid is a 4x3 height map with a text cell and a path over it, the
+// label needs escaping
+func newTestSVGGrid() *SVGGrid {
+
+	grid := NewSVGGrid(4, 3)
+	grid.CellColor = func(x, y int) string {
+		if x == 3 {
+			return ""
+		}
+		return []string{"#000000", "#444444", "#888888"}[(x+y)%3]
+	}
+	grid.CellText = func(x, y int) string {
+		if x == 0 && y == 0 {
+			return "S"
+		}
+		return ""
+	}
+	grid.AddPolyline([]SVGPoint{{0, 0}, {1, 0}, {1, 2}, {3, 2}}, CSSColor(BrightRed), "path <31 steps>")
+
+	return grid
+}
+
+// newTestSVGTree is a small file system, with an empty directory
+func newTestSVGTree() *SVGTree {
+	return NewSVGTree(&SVGTreeNode{Label: "/", Size: 300, Color: CSSColor(BrightBlue), Children: []*SVGTreeNode{
+		{Label: "a", Size: 200, Color: CSSColor(BrightBlue), Children: []*SVGTreeNode{
+			{Label: "e", Size: 0, Color: CSSColor(BrightBlue)},
+			{Label: "f.txt", Size: 200},
+		}},
+		{Label: "b & c.dat", Size: 100},
+	}})
+}
+
+func TestSVG(t *testing.T) {
+
+	tests := []struct {
+		name string
+		svg  io.WriterTo
+	}{
+		{"grid.svg", newTestSVGGrid()},
+		{"tree.svg", newTestSVGTree()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := test.svg.WriteTo(&buf); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, test.name, buf.Bytes())
+		})
+	}
+}
+
+// failingWriterTo fails after writing a part of the image
+type failingWriterTo struct{}
+
+func (failingWriterTo) WriteTo(w io.Writer) (int64, error) {
+	n, _ := io.WriteString(w, "<svg")
+	return int64(n), errors.New("out of ink")
+}
+
+func TestWriteSVGFile(t *testing.T) {
+
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "grid.svg")
+	if err := WriteSVGFile(path, newTestSVGGrid()); err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	_, _ = newTestSVGGrid().WriteTo(&want)
+	if got, err := os.ReadFile(path); err != nil || !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("got %q, %v, want the image in the file", got, err)
+	}
+
+	if err := WriteSVGFile(filepath.Join(dir, "failing.svg"), failingWriterTo{}); err == nil || !strings.Contains(err.Error(), "out of ink") {
+		t.Errorf("got %v, want the write error", err)
+	}
+
+	if err := WriteSVGFile(dir, newTestSVGGrid()); err == nil {
+		t.Error("got no error for a directory")
+	}
+}
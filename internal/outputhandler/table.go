@@ -0,0 +1,275 @@
+package outputhandler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Alignment is the horizontal alignment of a table column.
+type Alignment int
+
+const (
+	AlignLeft   Alignment = 0
+	AlignRight  Alignment = 1
+	AlignCenter Alignment = 2
+)
+
+// TableFormat is how a table is rendered.
+type TableFormat string
+
+const (
+	TableUnicode  TableFormat = "unicode"
+	TableASCII    TableFormat = "ascii"
+	TableMarkdown TableFormat = "markdown"
+	TableCSV      TableFormat = "csv"
+)
+
+var tableFormatFlag = flag.String("table", "", "table format: 'unicode', 'ascii', 'markdown' or 'csv' (default depends on the terminal)")
+
+// GetRequestedTableFormat returns the table format set on the commandline,
+// or the one the terminal is able to show.
+func GetRequestedTableFormat() TableFormat {
+	switch format := TableFormat(*tableFormatFlag); format {
+	case TableUnicode, TableASCII, TableMarkdown, TableCSV:
+		return format
+	}
+	if CanUseEmojis() {
+		return TableUnicode
+	}
+	return TableASCII
+}
+
+type TableColumn struct {
+	Header string
+	Align  Alignment
+	Color  TerminalColor // of the values, empty for default
+}
+
+// Table is a simple table with an optional title. Cells can have multiple lines.
+type Table struct {
+	Title   string
+	Columns []TableColumn
+	Rows    [][]string
+}
+
+func NewTable(title string, columns ...TableColumn) *Table {
+	return &Table{
+		Title:   title,
+		Columns: columns,
+		Rows:    make([][]string, 0),
+	}
+}
+
+// AddRow adds a row with the values formatted with fmt.Sprint().
+func (t *Table) AddRow(values ...interface{}) {
+
+	row := make([]string, len(t.Columns))
+	for idx := range row {
+		if idx < len(values) {
+			row[idx] = fmt.Sprint(values[idx])
+		}
+	}
+
+	t.Rows = append(t.Rows, row)
+}
+
+// Print prints the table to stdout in the format set on the commandline.
+func (t *Table) Print() {
+	for _, line := range t.Render(GetRequestedTableFormat()) {
+		fmt.Println(line)
+	}
+}
+
+// Render draws the table into lines in the given format.
+// Colors are only used for the bordered formats.
+func (t *Table) Render(format TableFormat) []string {
+	switch format {
+	case TableMarkdown:
+		return t.renderMarkdown()
+	case TableCSV:
+		return t.renderCSV()
+	case TableASCII:
+		return t.renderBordered(tableBorderASCII)
+	}
+	return t.renderBordered(tableBorderUnicode)
+}
+
+type tableBorder struct {
+	horizontal, vertical               string
+	topLeft, topMid, topRight          string
+	midLeft, midMid, midRight          string
+	bottomLeft, bottomMid, bottomRight string
+}
+
+var tableBorderUnicode = tableBorder{
+	horizontal: "─", vertical: "│",
+	topLeft: "┌", topMid: "┬", topRight: "┐",
+	midLeft: "├", midMid: "┼", midRight: "┤",
+	bottomLeft: "└", bottomMid: "┴", bottomRight: "┘",
+}
+
+var tableBorderASCII = tableBorder{
+	horizontal: "-", vertical: "|",
+	topLeft: "+", topMid: "+", topRight: "+",
+	midLeft: "+", midMid: "+", midRight: "+",
+	bottomLeft: "+", bottomMid: "+", bottomRight: "+",
+}
+
+func (t *Table) renderBordered(border tableBorder) []string {
+
+	widths := t.getColumnWidths()
+
+	separator := func(left, mid, right string) string {
+		parts := make([]string, len(widths))
+		for idx, width := range widths {
+			parts[idx] = strings.Repeat(border.horizontal, width+2)
+		}
+		return left + strings.Join(parts, mid) + right
+	}
+
+	lines := make([]string, 0, len(t.Rows)*2+4)
+	if len(t.Title) > 0 {
+		lines = append(lines, GetForeground(BrightYellow)+t.Title+GetReset())
+	}
+	lines = append(lines, separator(border.topLeft, border.topMid, border.topRight))
+
+	headers := make([]string, len(t.Columns))
+	for idx, column := range t.Columns {
+		headers[idx] = column.Header
+	}
+	lines = append(lines, t.renderBorderedRow(headers, widths, border, true)...)
+	lines = append(lines, separator(border.midLeft, border.midMid, border.midRight))
+
+	for _, row := range t.Rows {
+		lines = append(lines, t.renderBorderedRow(row, widths, border, false)...)
+	}
+	lines = append(lines, separator(border.bottomLeft, border.bottomMid, border.bottomRight))
+
+	return lines
+}
+
+func (t *Table) renderBorderedRow(row []string, widths []int, border tableBorder, isHeader bool) []string {
+
+	cellLines := make([][]string, len(row))
+	height := 1
+	for idx, cell := range row {
+		cellLines[idx] = strings.Split(cell, "\n")
+		if len(cellLines[idx]) > height {
+			height = len(cellLines[idx])
+		}
+	}
+
+	lines := make([]string, height)
+	for lineIdx := range lines {
+
+		line := border.vertical
+		for colIdx, column := range t.Columns {
+
+			var text string
+			if lineIdx < len(cellLines[colIdx]) {
+				text = cellLines[colIdx][lineIdx]
+			}
+
+			color := column.Color
+			if isHeader || len(color) == 0 {
+				color = DefaultColor
+			}
+			line += " " + GetForeground(color) + alignText(text, widths[colIdx], column.Align) + GetReset() + " " + border.vertical
+		}
+		lines[lineIdx] = line
+	}
+
+	return lines
+}
+
+func (t *Table) renderMarkdown() []string {
+
+	escape := func(cell string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(cell, "|", "\\|"), "\n", "<br>")
+	}
+
+	headers := make([]string, len(t.Columns))
+	aligns := make([]string, len(t.Columns))
+	for idx, column := range t.Columns {
+		headers[idx] = escape(column.Header)
+		switch column.Align {
+		case AlignRight:
+			aligns[idx] = "---:"
+		case AlignCenter:
+			aligns[idx] = ":---:"
+		default:
+			aligns[idx] = "---"
+		}
+	}
+
+	lines := make([]string, 0, len(t.Rows)+4)
+	if len(t.Title) > 0 {
+		lines = append(lines, "**"+t.Title+"**", "")
+	}
+	lines = append(lines, "| "+strings.Join(headers, " | ")+" |")
+	lines = append(lines, "| "+strings.Join(aligns, " | ")+" |")
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for idx, cell := range row {
+			cells[idx] = escape(cell)
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+	}
+
+	return lines
+}
+
+func (t *Table) renderCSV() []string {
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	headers := make([]string, len(t.Columns))
+	for idx, column := range t.Columns {
+		headers[idx] = column.Header
+	}
+	writer.Write(headers)
+	writer.WriteAll(t.Rows) // also flushes
+
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+func (t *Table) getColumnWidths() []int {
+
+	widths := make([]int, len(t.Columns))
+	for idx, column := range t.Columns {
+		widths[idx] = utf8.RuneCountInString(column.Header)
+	}
+
+	for _, row := range t.Rows {
+		for idx, cell := range row {
+			for _, line := range strings.Split(cell, "\n") {
+				if width := utf8.RuneCountInString(line); width > widths[idx] {
+					widths[idx] = width
+				}
+			}
+		}
+	}
+
+	return widths
+}
+
+func alignText(text string, width int, align Alignment) string {
+
+	padding := width - utf8.RuneCountInString(text)
+	if padding <= 0 {
+		return text
+	}
+
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", padding) + text
+	case AlignCenter:
+		return strings.Repeat(" ", padding/2) + text + strings.Repeat(" ", padding-padding/2)
+	}
+	return text + strings.Repeat(" ", padding)
+}
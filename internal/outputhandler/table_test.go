@@ -0,0 +1,60 @@
+package outputhandler
+
+import "testing"
+
+func newTestTable() *Table {
+
+	table := NewTable("Day 10",
+		TableColumn{Header: "Part"},
+		TableColumn{Header: "Answer", Align: AlignRight, Color: BrightGreen},
+		TableColumn{Header: "Note", Align: AlignCenter},
+	)
+	table.AddRow("Part1", 13140, "a|b")
+	table.AddRow("Part2", "##..\n..##", "két")
+	table.AddRow("Part3") // missing values are empty
+
+	return table
+}
+
+func TestTableRender(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		terminal TerminalInfo
+		format   TableFormat
+	}{
+		{"table_unicode", unicodeTerminal, TableUnicode},
+		{"table_ascii", plainTerminal, TableASCII},
+		{"table_markdown", plainTerminal, TableMarkdown},
+		{"table_csv", plainTerminal, TableCSV},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withTerminal(t, test.terminal)
+			checkGoldenLines(t, test.name, newTestTable().Render(test.format))
+		})
+	}
+}
+
+func TestAlignText(t *testing.T) {
+
+	tests := []struct {
+		text  string
+		width int
+		align Alignment
+		want  string
+	}{
+		{"ab", 5, AlignLeft, "ab   "},
+		{"ab", 5, AlignRight, "   ab"},
+		{"ab", 5, AlignCenter, " ab  "},
+		{"ár", 4, AlignRight, "  ár"}, // runes, not bytes
+		{"long", 2, AlignLeft, "long"},
+	}
+
+	for _, test := range tests {
+		if got := alignText(test.text, test.width, test.align); got != test.want {
+			t.Errorf("alignText(%q, %d, %d): got %q, want %q", test.text, test.width, test.align, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+//go:build !windows
+
+package outputhandler
+
+import (
+	"os"
+	"strings"
+)
+
+// GetTerminalInfo guesses the terminal features from the environment
+// variables as there is no process tree to walk like on Windows.
+//
+// NOTE: This method is not even close to accurate, but it's good enough
+// for what it's used for here. :)
+func GetTerminalInfo() (*TerminalInfo, *RunningEnvironment, error) {
+
+	term := os.Getenv("TERM")
+
+	terminal := TerminalInfo{Name: term}
+	env := RunningEnvironment{Name: os.Getenv("TERM_PROGRAM")}
+
+	if !isOutputTerminal() || term == "" || term == "dumb" {
+		return &terminal, &env, nil
+	}
+
+	terminal.CSICursorSupport = true
+	terminal.CSIColorSupport = os.Getenv("NO_COLOR") == ""
+
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToUpper(os.Getenv(name)); locale != "" {
+			terminal.EmojiSupport = strings.Contains(locale, "UTF-8") || strings.Contains(locale, "UTF8")
+			break
+		}
+	}
+
+	terminal.SixelSupport, terminal.KittyGraphicsSupport = guessGraphicsSupport(term, env.Name)
+
+	return &terminal, &env, nil
+}
+
+// guessGraphicsSupport checks the terminals known to show images.
+// Asking the terminal itself would need raw mode and a read timeout.
+func guessGraphicsSupport(term string, program string) (bool, bool) {
+
+	switch {
+	case term == "xterm-kitty" || os.Getenv("KITTY_WINDOW_ID") != "":
+		return false, true
+	case program == "WezTerm":
+		return true, true
+	case program == "ghostty" || term == "xterm-ghostty":
+		return false, true
+	case program == "iTerm.app":
+		return true, false
+	}
+
+	for _, name := range []string{"foot", "mlterm", "contour", "sixel"} {
+		if strings.Contains(term, name) {
+			return true, false
+		}
+	}
+
+	return false, false
+}
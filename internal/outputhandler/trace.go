@@ -0,0 +1,133 @@
+package outputhandler
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TraceLevel is how detailed the trace output is.
+type TraceLevel int
+
+const (
+	TraceOff     TraceLevel = iota
+	TraceDebug              // a few lines per step, like per round summaries
+	TraceVerbose            // everything, like every single move
+)
+
+// traceLevelFlag is a flag that works as a bool (-v) or takes the level (-v=2, -v=verbose)
+type traceLevelFlag struct {
+	level TraceLevel
+	isSet bool
+}
+
+func (f *traceLevelFlag) String() string {
+	return strconv.Itoa(int(f.level))
+}
+
+func (f *traceLevelFlag) IsBoolFlag() bool {
+	return true
+}
+
+func (f *traceLevelFlag) Set(value string) error {
+
+	switch strings.ToLower(value) {
+	case "true", "debug", "1":
+		f.level = TraceDebug
+	case "verbose", "2":
+		f.level = TraceVerbose
+	case "false", "off", "0":
+		f.level = TraceOff
+	default:
+		return fmt.Errorf("unknown trace level '%s'", value)
+	}
+	f.isSet = true
+
+	return nil
+}
+
+var traceLevel traceLevelFlag
+var traceFilter = flag.String("trace", "", "trace only the listed `components`, like day17.moves or day11 (comma separated, * is a wildcard)")
+
+func init() {
+	flag.Var(&traceLevel, "v", "print debug traces, -v=verbose for every detail")
+}
+
+// Tracer prints debug lines of a day's component to stderr when enabled on the command line:
+//
+//	-v                      debug traces of all components
+//	-v=verbose              all traces of all components
+//	-trace=day17.moves      all traces of the day17 "moves" component only
+//	-trace=day11 -v         debug traces of all day11 components
+//
+// Traces don't end up in the recordings or the exports, those get stdout only.
+type Tracer struct {
+	name  string
+	level TraceLevel
+	once  sync.Once
+}
+
+// NewTracer creates the tracer for the component of the day, like NewTracer("day17", "moves").
+// Can be a package level variable, the command line is checked on first use.
+func NewTracer(day string, component string) *Tracer {
+	return &Tracer{name: day + "." + component}
+}
+
+// IsEnabled tells if the level is traced. Can be used to skip the work of
+// preparing the trace in hot loops.
+func (t *Tracer) IsEnabled(level TraceLevel) bool {
+	t.once.Do(func() {
+		t.level = getTraceLevelOf(t.name)
+	})
+	return level <= t.level
+}
+
+// Debugf prints a debug trace line.
+func (t *Tracer) Debugf(format string, args ...interface{}) {
+	t.printf(TraceDebug, format, args...)
+}
+
+// Verbosef prints a verbose trace line.
+func (t *Tracer) Verbosef(format string, args ...interface{}) {
+	t.printf(TraceVerbose, format, args...)
+}
+
+func (t *Tracer) printf(level TraceLevel, format string, args ...interface{}) {
+
+	if !t.IsEnabled(level) {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "[%s] %s\n", t.name, strings.TrimSuffix(fmt.Sprintf(format, args...), "\n"))
+}
+
+// getTraceLevelOf checks the command line for the component
+func getTraceLevelOf(name string) TraceLevel {
+
+	if len(*traceFilter) == 0 {
+		return traceLevel.level
+	}
+
+	for _, pattern := range strings.Split(*traceFilter, ",") {
+
+		pattern = strings.TrimSpace(pattern)
+		if !strings.Contains(pattern, ".") {
+			pattern += ".*"
+		}
+
+		if matched, _ := path.Match(pattern, name); !matched {
+			continue
+		}
+
+		if traceLevel.isSet {
+			return traceLevel.level
+		}
+		return TraceVerbose
+	}
+
+	return TraceOff
+}
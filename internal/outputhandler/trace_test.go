@@ -0,0 +1,55 @@
+package outputhandler
+
+import "testing"
+
+func TestGetTraceLevelOf(t *testing.T) {
+
+	tests := []struct {
+		filter string
+		level  traceLevelFlag
+		name   string
+		want   TraceLevel
+	}{
+		// without a filter, -v alone decides
+		{"", traceLevelFlag{}, "day17.moves", TraceOff},
+		{"", traceLevelFlag{TraceDebug, true}, "day17.moves", TraceDebug},
+		{"", traceLevelFlag{TraceVerbose, true}, "day17.moves", TraceVerbose},
+
+		// exact names, verbose unless -v is given too
+		{"day17.moves", traceLevelFlag{}, "day17.moves", TraceVerbose},
+		{"day17.moves", traceLevelFlag{TraceDebug, true}, "day17.moves", TraceDebug},
+		{"day17.moves", traceLevelFlag{}, "day17.rocks", TraceOff},
+		{"day17.moves", traceLevelFlag{}, "day11.moves", TraceOff},
+
+		// a day alone is all of its components, but not the days it's a prefix of
+		{"day11", traceLevelFlag{}, "day11.rounds", TraceVerbose},
+		{"day11", traceLevelFlag{TraceDebug, true}, "day11.rounds", TraceDebug},
+		{"day1", traceLevelFlag{}, "day11.rounds", TraceOff},
+		{"day17.move", traceLevelFlag{}, "day17.moves", TraceOff},
+
+		// wildcards
+		{"day1*", traceLevelFlag{}, "day17.moves", TraceVerbose},
+		{"day1*", traceLevelFlag{}, "day20.mixing", TraceOff},
+		{"*.moves", traceLevelFlag{}, "day17.moves", TraceVerbose},
+		{"*.moves", traceLevelFlag{}, "day17.rocks", TraceOff},
+		{"day17.r*", traceLevelFlag{}, "day17.rocks", TraceVerbose},
+		{"*", traceLevelFlag{}, "day09.knots", TraceVerbose},
+
+		// lists, spaces around the names are ignored
+		{"day17.moves,day11", traceLevelFlag{}, "day11.rounds", TraceVerbose},
+		{"day17.moves, day11", traceLevelFlag{}, "day11.rounds", TraceVerbose},
+		{"day17.moves,day11", traceLevelFlag{}, "day17.moves", TraceVerbose},
+		{"day17.moves,day11", traceLevelFlag{}, "day17.rocks", TraceOff},
+		{"day17.moves,day11", traceLevelFlag{TraceOff, true}, "day11.rounds", TraceOff},
+	}
+
+	filter, level := *traceFilter, traceLevel
+	defer func() { *traceFilter, traceLevel = filter, level }()
+
+	for _, test := range tests {
+		*traceFilter, traceLevel = test.filter, test.level
+		if got := getTraceLevelOf(test.name); got != test.want {
+			t.Errorf("getTraceLevelOf(%q) with -trace=%q -v=%d = %d, want %d", test.name, test.filter, test.level.level, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package outputhandler
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TreeNode is what PrintTree needs from a node of any tree.
+type TreeNode[T any] interface {
+	Label() string
+	Children() []T
+}
+
+// TreeOptions are the optional settings for PrintTree.
+type TreeOptions[T any] struct {
+	MaxDepth     int               // deeper nodes are not shown, 0 for unlimited
+	CollapseOver int               // children above this count are collapsed, 0 for never
+	Less         func(a, b T) bool // sorts the children, nil keeps the order
+
+	TreeColor TerminalColor
+	Color     func(node T) TerminalColor           // color of the label
+	Annotate  func(node T) (string, TerminalColor) // text after the label
+}
+
+type treeLines struct {
+	branch, last, pipe, space string
+}
+
+var treeLinesUnicode = treeLines{branch: "├── ", last: "└── ", pipe: "│   ", space: "    "}
+var treeLinesASCII = treeLines{branch: "|-- ", last: "`-- ", pipe: "|   ", space: "    "}
+
+// PrintTree prints the tree starting from root to stdout.
+func PrintTree[T TreeNode[T]](root T, options TreeOptions[T]) {
+	for _, line := range RenderTree(root, options) {
+		fmt.Println(line)
+	}
+}
+
+// RenderTree draws the tree into lines. ASCII lines are used when there is no Unicode support.
+func RenderTree[T TreeNode[T]](root T, options TreeOptions[T]) []string {
+
+	lines := treeLinesUnicode
+	if !CanUseEmojis() {
+		lines = treeLinesASCII
+	}
+	if len(options.TreeColor) == 0 {
+		options.TreeColor = DefaultColor
+	}
+
+	return renderTree_recurse(root, options, lines, make([]bool, 0), make([]string, 0))
+}
+
+func renderTree_recurse[T TreeNode[T]](node T, options TreeOptions[T], lines treeLines, isLastList []bool, rendered []string) []string {
+
+	children := node.Children()
+	if options.Less != nil {
+		children = append([]T{}, children...)
+		sort.SliceStable(children, func(i, j int) bool {
+			return options.Less(children[i], children[j])
+		})
+	}
+
+	line := GetForeground(options.TreeColor) + treePrefix(isLastList, lines) + formatTreeLabel(node, options)
+	if options.MaxDepth > 0 && len(isLastList) >= options.MaxDepth && len(children) > 0 {
+		line += GetForeground(options.TreeColor) + fmt.Sprintf(" [+%d]", len(children))
+		children = nil
+	}
+	rendered = append(rendered, line+GetReset())
+
+	var hidden int
+	if options.CollapseOver > 0 && len(children) > options.CollapseOver {
+		hidden = len(children) - options.CollapseOver
+		children = children[:options.CollapseOver]
+	}
+
+	for childIdx := range children {
+		isLast := childIdx == len(children)-1 && hidden == 0
+		rendered = renderTree_recurse(children[childIdx], options, lines, append(isLastList, isLast), rendered)
+	}
+
+	if hidden > 0 {
+		rendered = append(rendered, GetForeground(options.TreeColor)+treePrefix(append(isLastList, true), lines)+fmt.Sprintf("... %d more", hidden)+GetReset())
+	}
+
+	return rendered
+}
+
+func treePrefix(isLastList []bool, lines treeLines) string {
+
+	if len(isLastList) == 0 {
+		return ""
+	}
+
+	var prefix string
+	for _, isLast := range isLastList[:len(isLastList)-1] {
+		if isLast {
+			prefix += lines.space
+		} else {
+			prefix += lines.pipe
+		}
+	}
+	if isLastList[len(isLastList)-1] {
+		prefix += lines.last
+	} else {
+		prefix += lines.branch
+	}
+
+	return prefix
+}
+
+func formatTreeLabel[T TreeNode[T]](node T, options TreeOptions[T]) string {
+
+	color := DefaultColor
+	if options.Color != nil {
+		color = options.Color(node)
+	}
+	label := GetForeground(color) + node.Label()
+
+	if options.Annotate != nil {
+		if annotation, annotationColor := options.Annotate(node); len(annotation) > 0 {
+			label += " " + GetForeground(annotationColor) + annotation
+		}
+	}
+
+	return label
+}
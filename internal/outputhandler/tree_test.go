@@ -0,0 +1,79 @@
+package outputhandler
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testTreeNode struct {
+	name     string
+	size     int
+	children []*testTreeNode
+}
+
+func (n *testTreeNode) Label() string {
+	return n.name
+}
+
+func (n *testTreeNode) Children() []*testTreeNode {
+	return n.children
+}
+
+func newTestTree() *testTreeNode {
+	return &testTreeNode{name: "/", children: []*testTreeNode{
+		{name: "b.txt", size: 14848514},
+		{name: "a", children: []*testTreeNode{
+			{name: "e", children: []*testTreeNode{
+				{name: "i", size: 584},
+			}},
+			{name: "f", size: 29116},
+			{name: "g", size: 2557},
+			{name: "h.lst", size: 62596},
+		}},
+		{name: "c.dat", size: 8504156},
+	}}
+}
+
+func TestRenderTree(t *testing.T) {
+
+	isDir := func(node *testTreeNode) bool {
+		return node.children != nil
+	}
+
+	tests := []struct {
+		name     string
+		terminal TerminalInfo
+		options  TreeOptions[*testTreeNode]
+	}{
+		{"tree_ascii", plainTerminal, TreeOptions[*testTreeNode]{}},
+		{"tree_unicode", unicodeTerminal, TreeOptions[*testTreeNode]{
+			TreeColor: Gray,
+			Color: func(node *testTreeNode) TerminalColor {
+				if isDir(node) {
+					return BrightCyan
+				}
+				return BrightGreen
+			},
+			Annotate: func(node *testTreeNode) (string, TerminalColor) {
+				if isDir(node) {
+					return "", DefaultColor
+				}
+				return fmt.Sprintf("(%d)", node.size), BrightMagenta
+			},
+		}},
+		{"tree_sorted", plainTerminal, TreeOptions[*testTreeNode]{
+			Less: func(a, b *testTreeNode) bool {
+				return isDir(a) && !isDir(b) || isDir(a) == isDir(b) && a.name < b.name
+			},
+		}},
+		{"tree_depth", plainTerminal, TreeOptions[*testTreeNode]{MaxDepth: 1}},
+		{"tree_collapse", plainTerminal, TreeOptions[*testTreeNode]{CollapseOver: 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withTerminal(t, test.terminal)
+			checkGoldenLines(t, test.name, RenderTree(newTestTree(), test.options))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package outputhandler
+
+import (
+	"fmt"
+)
+
+// Viewport is a window into a grid that is bigger than the terminal.
+// It crops the grid lines to the visible area and scrolls to follow
+// a point of interest. Zero Width/Height fits it to the terminal.
+type Viewport struct {
+	X, Y          int // top left cell of the visible area
+	Width, Height int
+	Margin        int // how close the followed point gets to the edges before scrolling
+}
+
+func NewViewport() *Viewport {
+	return &Viewport{Margin: 3}
+}
+
+const fallbackTerminalWidth = 80
+const fallbackTerminalHeight = 24
+
+// GetSize returns the visible area in cells, one terminal line is kept for the status.
+func (v *Viewport) GetSize() (int, int) {
+
+	width, height := v.Width, v.Height
+	if width > 0 && height > 0 {
+		return width, height
+	}
+
+	termWidth, termHeight, err := GetTerminalSize()
+	if err != nil || termWidth <= 0 || termHeight <= 1 {
+		termWidth, termHeight = fallbackTerminalWidth, fallbackTerminalHeight
+	}
+
+	if width <= 0 {
+		width = termWidth
+	}
+	if height <= 0 {
+		height = termHeight - 1
+	}
+	return width, height
+}
+
+// Follow scrolls the viewport so the point stays Margin cells away from the edges.
+func (v *Viewport) Follow(x, y int) {
+
+	width, height := v.GetSize()
+	marginX := getMin(v.Margin, (width-1)/2)
+	marginY := getMin(v.Margin, (height-1)/2)
+
+	if x < v.X+marginX {
+		v.X = x - marginX
+	} else if x > v.X+width-1-marginX {
+		v.X = x - (width - 1 - marginX)
+	}
+
+	if y < v.Y+marginY {
+		v.Y = y - marginY
+	} else if y > v.Y+height-1-marginY {
+		v.Y = y - (height - 1 - marginY)
+	}
+}
+
+// Pan moves the viewport by the given number of cells.
+func (v *Viewport) Pan(dx, dy int) {
+	v.X += dx
+	v.Y += dy
+}
+
+// clamp keeps the viewport inside the grid if the grid is big enough
+func (v *Viewport) clamp(gridWidth, gridHeight int) {
+
+	width, height := v.GetSize()
+
+	if v.X > gridWidth-width {
+		v.X = gridWidth - width
+	}
+	if v.X < 0 {
+		v.X = 0
+	}
+
+	if v.Y > gridHeight-height {
+		v.Y = gridHeight - height
+	}
+	if v.Y < 0 {
+		v.Y = 0
+	}
+}
+
+// Crop returns the visible part of the grid lines. Lines must not contain
+// format strings as those would be cut into pieces.
+func (v *Viewport) Crop(lines []string) []string {
+
+	gridWidth := 0
+	for _, line := range lines {
+		if len([]rune(line)) > gridWidth {
+			gridWidth = len([]rune(line))
+		}
+	}
+	v.clamp(gridWidth, len(lines))
+
+	width, height := v.GetSize()
+	lastLine := getMin(v.Y+height, len(lines))
+
+	cropped := make([]string, 0, height)
+	for _, line := range lines[v.Y:lastLine] {
+		runes := []rune(line)
+		if v.X >= len(runes) {
+			cropped = append(cropped, "")
+			continue
+		}
+		cropped = append(cropped, string(runes[v.X:getMin(v.X+width, len(runes))]))
+	}
+
+	return cropped
+}
+
+// GetStatus returns the line describing which part of the grid is visible.
+func (v *Viewport) GetStatus(lines []string) string {
+
+	width, height := v.GetSize()
+
+	gridWidth := 0
+	for _, line := range lines {
+		if len([]rune(line)) > gridWidth {
+			gridWidth = len([]rune(line))
+		}
+	}
+
+	return fmt.Sprintf("x: %d-%d/%d, y: %d-%d/%d", v.X, getMin(v.X+width, gridWidth)-1, gridWidth, v.Y, getMin(v.Y+height, len(lines))-1, len(lines))
+}
+
+// Print prints the visible part of the grid with the status below.
+func (v *Viewport) Print(lines []string) {
+
+	for _, line := range v.Crop(lines) {
+		fmt.Println(line)
+	}
+	fmt.Println(GetForeground(Gray) + v.GetStatus(lines) + GetReset())
+}
+
+// PrintFrame is Print() for animations, it overwrites the previous frame
+// when cursor control is available.
+func (v *Viewport) PrintFrame(lines []string) {
+	fmt.Print(GetCursorHome() + GetClearScreen())
+	v.Print(lines)
+}
+
+func getMin(val1, val2 int) int {
+	if val1 < val2 {
+		return val1
+	}
+	return val2
+}
@@ -0,0 +1,154 @@
+package outputhandler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withoutTerminal makes GetTerminalSize() fail, like when the output is piped
+func withoutTerminal(t *testing.T) {
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := terminalOutput
+	terminalOutput = file
+	t.Cleanup(func() {
+		terminalOutput = output
+		file.Close()
+	})
+}
+
+func TestViewportGetSize(t *testing.T) {
+
+	withoutTerminal(t)
+
+	tests := []struct {
+		width, height         int
+		wantWidth, wantHeight int
+	}{
+		{10, 5, 10, 5},
+		{10, 0, 10, fallbackTerminalHeight - 1}, // a line is kept for the status
+		{0, 7, fallbackTerminalWidth, 7},
+		{0, 0, fallbackTerminalWidth, fallbackTerminalHeight - 1},
+		{-1, -1, fallbackTerminalWidth, fallbackTerminalHeight - 1},
+	}
+
+	for _, test := range tests {
+		v := Viewport{Width: test.width, Height: test.height}
+		if width, height := v.GetSize(); width != test.wantWidth || height != test.wantHeight {
+			t.Errorf("GetSize() of %dx%d = %dx%d, want %dx%d", test.width, test.height, width, height, test.wantWidth, test.wantHeight)
+		}
+	}
+}
+
+func TestViewportFollow(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		width, height int
+		margin        int
+		startX        int
+		startY        int
+		x, y          int
+		wantX, wantY  int
+	}{
+		{"inside the margins", 10, 5, 3, 0, 0, 5, 2, 0, 0},
+		{"on the right margin", 10, 5, 3, 0, 0, 6, 2, 0, 0},
+		{"past the right margin", 10, 5, 3, 0, 0, 8, 2, 2, 0},
+		{"past the bottom margin", 10, 5, 3, 0, 0, 3, 4, 0, 2},
+		{"past the top left margins", 10, 5, 3, 10, 10, 12, 11, 9, 9},
+		{"not clamped to the grid", 10, 5, 3, 0, 0, 0, 0, -3, -2},
+		{"margin over half the size", 4, 3, 3, 0, 0, 5, 5, 3, 4},
+		{"no margin", 4, 3, 0, 0, 0, 4, 2, 1, 0},
+	}
+
+	for _, test := range tests {
+		v := Viewport{X: test.startX, Y: test.startY, Width: test.width, Height: test.height, Margin: test.margin}
+		v.Follow(test.x, test.y)
+		if v.X != test.wantX || v.Y != test.wantY {
+			t.Errorf("%s: Follow(%d, %d) from %d,%d = %d,%d, want %d,%d", test.name, test.x, test.y, test.startX, test.startY, v.X, v.Y, test.wantX, test.wantY)
+		}
+	}
+}
+
+func TestViewportPan(t *testing.T) {
+
+	v := Viewport{X: 2, Y: 3, Width: 10, Height: 5}
+	v.Pan(-5, 1)
+	if v.X != -3 || v.Y != 4 {
+		t.Errorf("Pan(-5, 1) from 2,3 = %d,%d, want -3,4 (clamped by Crop only)", v.X, v.Y)
+	}
+}
+
+func TestViewportClamp(t *testing.T) {
+
+	tests := []struct {
+		name                  string
+		x, y                  int
+		gridWidth, gridHeight int
+		wantX, wantY          int
+	}{
+		{"inside", 5, 3, 30, 20, 5, 3},
+		{"past the bottom right", 25, 18, 30, 20, 20, 15},
+		{"past the top left", -3, -2, 30, 20, 0, 0},
+		{"exact fit", 1, 1, 10, 5, 0, 0},
+		{"smaller grid", 4, 2, 6, 3, 0, 0},
+		{"empty grid", 4, 2, 0, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		v := Viewport{X: test.x, Y: test.y, Width: 10, Height: 5}
+		v.clamp(test.gridWidth, test.gridHeight)
+		if v.X != test.wantX || v.Y != test.wantY {
+			t.Errorf("%s: clamp(%d, %d) from %d,%d = %d,%d, want %d,%d", test.name, test.gridWidth, test.gridHeight, test.x, test.y, v.X, v.Y, test.wantX, test.wantY)
+		}
+	}
+}
+
+func TestViewportCrop(t *testing.T) {
+
+	grid := []string{"abcdef", "ghijkl", "mnopqr"}
+
+	tests := []struct {
+		name         string
+		lines        []string
+		x, y         int
+		want         []string
+		wantX, wantY int
+	}{
+		{"inside", grid, 1, 1, []string{"hijk", "nopq"}, 1, 1},
+		{"clamped to the bottom right", grid, 5, 5, []string{"ijkl", "opqr"}, 2, 1},
+		{"clamped to the top left", grid, -2, -1, []string{"abcd", "ghij"}, 0, 0},
+		{"smaller grid", []string{"ab", "c"}, 3, 1, []string{"ab", "c"}, 0, 0},
+		{"ragged lines", []string{"abcdef", "a"}, 3, 0, []string{"cdef", ""}, 2, 0},
+		{"runes", []string{"█▀▄█▀▄"}, 1, 0, []string{"▀▄█▀"}, 1, 0},
+		{"empty grid", []string{}, 1, 1, []string{}, 0, 0},
+	}
+
+	for _, test := range tests {
+		v := Viewport{X: test.x, Y: test.y, Width: 4, Height: 2}
+		got := v.Crop(test.lines)
+		if strings.Join(got, "|") != strings.Join(test.want, "|") || len(got) != len(test.want) || v.X != test.wantX || v.Y != test.wantY {
+			t.Errorf("%s: Crop() from %d,%d = %q at %d,%d, want %q at %d,%d", test.name, test.x, test.y, got, v.X, v.Y, test.want, test.wantX, test.wantY)
+		}
+	}
+}
+
+func TestViewportGetStatus(t *testing.T) {
+
+	v := Viewport{X: 2, Y: 1, Width: 4, Height: 2}
+	if got, want := v.GetStatus([]string{"abcdef", "ghijkl", "mnopqr"}), "x: 2-5/6, y: 1-2/3"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// a grid smaller than the viewport ends at its own size
+	v = Viewport{Width: 4, Height: 2}
+	if got, want := v.GetStatus([]string{"ab"}), "x: 0-1/2, y: 0-0/1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
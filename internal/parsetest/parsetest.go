@@ -0,0 +1,34 @@
+// Helpers for the fuzz tests of the days' input parsers.
+//
+// The fuzz tests check that a parser never panics, whatever the input is: it either
+// accepts the input or reports what's wrong with it in an inputhandler.ParseError.
+// Run one for a while like:
+//
+//	go test ./cmd/2022/day05 -run=^$ -fuzz=FuzzProcessInput -fuzztime=30s
+package parsetest
+
+import (
+	"AoC22/internal/inputhandler"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Lines splits the input to lines the same way inputhandler.ReadInput() does for a file.
+func Lines(input string) []string {
+	return strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+}
+
+// CheckError fails the test if the parser's error is not a ParseError, nil is fine.
+func CheckError(t testing.TB, err error) {
+	t.Helper()
+
+	if err == nil {
+		return
+	}
+
+	var parseErr *inputhandler.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("the error is not a ParseError: %v", err)
+	}
+}
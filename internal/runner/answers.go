@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"AoC22/internal/outputhandler"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Answers are the accepted answers of a day for an input.
+type Answers struct {
+	Checksum string            `json:"checksum"` // of the input, see inputhandler.GetInputChecksum()
+	Answers  map[string]string `json:"answers"`  // by part
+}
+
+// Verdict is how an answer compares to the accepted one.
+type Verdict string
+
+const (
+	Verified   Verdict = "verified"
+	Regressed  Verdict = "regressed"
+	Unverified Verdict = "unverified" // no accepted answer for this input
+	Stopped    Verdict = "stopped"    // by a timeout or Ctrl-C, the answer is the progress
+)
+
+// LoadAnswers loads the accepted answers, nil if there are none yet.
+func LoadAnswers(path string) (*Answers, error) {
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file '%s': %w", path, err)
+	}
+
+	var answers Answers
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("couldn't parse file '%s': %w", path, err)
+	}
+
+	return &answers, nil
+}
+
+// SaveAnswers saves the answers of the record as the accepted ones.
+// Stopped parts are left out.
+func SaveAnswers(path string, record *outputhandler.RunRecord) error {
+
+	answers := Answers{Checksum: record.Checksum, Answers: make(map[string]string)}
+	for _, part := range record.Results {
+		if len(part.Stopped) == 0 {
+			answers.Answers[part.Part] = part.AnswerText()
+		}
+	}
+
+	data, err := json.MarshalIndent(answers, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("couldn't write file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// CheckExample compares the part's answer to the worked example's, for the records
+// of the runs with -example.
+func CheckExample(part outputhandler.PartRecord) Verdict {
+
+	if len(part.Stopped) > 0 {
+		return Stopped
+	}
+	if len(part.Expected) == 0 {
+		return Unverified
+	}
+	if part.AnswerText() != part.Expected {
+		return Regressed
+	}
+
+	return Verified
+}
+
+// Check compares the part's answer to the accepted one. Answers are only
+// compared if they are for the same input.
+func (a *Answers) Check(record *outputhandler.RunRecord, part outputhandler.PartRecord) Verdict {
+
+	if len(part.Stopped) > 0 {
+		return Stopped
+	}
+	if a == nil || a.Checksum != record.Checksum {
+		return Unverified
+	}
+
+	accepted, ok := a.Answers[part.Part]
+	if !ok {
+		return Unverified
+	}
+	if part.AnswerText() != accepted {
+		return Regressed
+	}
+
+	return Verified
+}
@@ -0,0 +1,129 @@
+package runner
+
+import (
+	"AoC22/internal/outputhandler"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testRecordJSON is a record as a day prints it with -output json, with an answer
+// too big for a float64 and an image
+const testRecordJSON = `{"year":2022,"day":10,"title":"Result","input":"input.txt","checksum":"abc",` +
+	`"results":[{"part":"Part1","type":"int","answer":9007199254740993,"elapsed_ns":10},` +
+	`{"part":"Part2","type":"image","answer":["#..#","#..#"],"elapsed_ns":20},` +
+	`{"part":"Part3","type":"string","answer":"31807 rows","elapsed_ns":30,"stopped":"interrupted"}]}`
+
+func TestLoadAnswersMissing(t *testing.T) {
+
+	answers, err := LoadAnswers(filepath.Join(t.TempDir(), "day10.answers.json"))
+	if answers != nil || err != nil {
+		t.Errorf("got %v, %v, want no answers and no error", answers, err)
+	}
+}
+
+func TestLoadAnswersInvalid(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "day10.answers.json")
+	if err := os.WriteFile(path, []byte("{\"checksum\": 5}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadAnswers(path); err == nil || !strings.Contains(err.Error(), "couldn't parse file") {
+		t.Errorf("got error %v, want a parse error", err)
+	}
+}
+
+func TestSaveAndLoadAnswers(t *testing.T) {
+
+	record, err := DecodeRecord([]byte(testRecordJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "day10.answers.json")
+	if err := SaveAnswers(path, record); err != nil {
+		t.Fatal(err)
+	}
+	answers, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if answers.Checksum != "abc" {
+		t.Errorf("got checksum %q, want %q", answers.Checksum, "abc")
+	}
+	want := map[string]string{"Part1": "9007199254740993", "Part2": "#..#\n#..#"} // the stopped part is left out
+	if len(answers.Answers) != len(want) {
+		t.Errorf("got answers %q, want %q", answers.Answers, want)
+	}
+	for part, answer := range want {
+		if answers.Answers[part] != answer {
+			t.Errorf("%s: got %q, want %q", part, answers.Answers[part], answer)
+		}
+	}
+
+	for _, part := range record.Results {
+		wantVerdict := Verified
+		if part.Part == "Part3" {
+			wantVerdict = Stopped
+		}
+		if got := answers.Check(record, part); got != wantVerdict {
+			t.Errorf("%s: got %s, want %s", part.Part, got, wantVerdict)
+		}
+	}
+}
+
+func TestAnswersCheck(t *testing.T) {
+
+	record, err := DecodeRecord([]byte(testRecordJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	part := record.Results[0]
+
+	tests := []struct {
+		name    string
+		answers *Answers
+		want    Verdict
+	}{
+		{"no answers", nil, Unverified},
+		{"other input", &Answers{Checksum: "def", Answers: map[string]string{"Part1": "1"}}, Unverified},
+		{"no answer for the part", &Answers{Checksum: "abc", Answers: map[string]string{}}, Unverified},
+		{"same answer", &Answers{Checksum: "abc", Answers: map[string]string{"Part1": "9007199254740993"}}, Verified},
+		{"other answer", &Answers{Checksum: "abc", Answers: map[string]string{"Part1": "9007199254740992"}}, Regressed},
+	}
+
+	for _, test := range tests {
+		if got := test.answers.Check(record, part); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+
+	// a stopped part isn't a mismatch, whatever the accepted answer
+	stopped := outputhandler.PartRecord{Part: "Part1", Type: outputhandler.AnswerString, Answer: "2 of 20 rounds", Stopped: "timed out after 30s"}
+	if got := tests[3].answers.Check(record, stopped); got != Stopped {
+		t.Errorf("stopped: got %s, want %s", got, Stopped)
+	}
+}
+
+func TestCheckExample(t *testing.T) {
+
+	tests := []struct {
+		name string
+		part outputhandler.PartRecord
+		want Verdict
+	}{
+		{"no example answer", outputhandler.PartRecord{Type: outputhandler.AnswerInt, Answer: 24000}, Unverified},
+		{"same answer", outputhandler.PartRecord{Type: outputhandler.AnswerInt, Answer: 24000, Expected: "24000"}, Verified},
+		{"other answer", outputhandler.PartRecord{Type: outputhandler.AnswerInt, Answer: 24001, Expected: "24000"}, Regressed},
+		{"stopped", outputhandler.PartRecord{Type: outputhandler.AnswerString, Answer: "2 of 3 elves", Expected: "24000", Stopped: "interrupted"}, Stopped},
+	}
+
+	for _, test := range tests {
+		if got := CheckExample(test.part); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"AoC22/internal/calendar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GeneratedInput is a random input printed by a day's -gen.
+type GeneratedInput struct {
+	Input  []byte
+	Seed   int64
+	Params []string // the flags to solve it with, like "-param", "row=10"
+}
+
+// Generate runs the day's built binary with -gen, size 0 is the day's default size
+// and seed 0 a random seed.
+func (r *Runner) Generate(ctx context.Context, day calendar.Day, seed int64, size int) (GeneratedInput, error) {
+
+	generated := GeneratedInput{Seed: seed}
+	if day.NoInput {
+		return generated, fmt.Errorf("%s has no input to generate", day.ID())
+	}
+
+	args := []string{"-gen"}
+	if seed != 0 {
+		args = append(args, "-seed", strconv.FormatInt(seed, 10))
+	}
+	if size > 0 {
+		args = append(args, "-size", strconv.Itoa(size))
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.GetBinaryPath(day), args...)
+	cmd.Dir = r.Root
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return generated, fmt.Errorf("%s", getErrorMessage(stderr.String(), exitErr))
+		}
+		return generated, err
+	}
+	generated.Input = stdout.Bytes()
+
+	// the seed and the parameters are printed to stderr
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "seed: "):
+			if value, err := strconv.ParseInt(strings.TrimPrefix(line, "seed: "), 10, 64); err == nil {
+				generated.Seed = value
+			}
+		case strings.HasPrefix(line, "solve with: "):
+			generated.Params = strings.Fields(strings.TrimPrefix(line, "solve with: "))
+		}
+	}
+
+	return generated, nil
+}
@@ -0,0 +1,70 @@
+package runner
+
+import (
+	"AoC22/internal/calendar"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestGenerateRoundTrip writes the -gen output of every day to a file as is, like
+// "dayNN -gen > random.txt", and solves it with -f.
+func TestGenerateRoundTrip(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("builds all the days")
+	}
+
+	r, err := NewRunner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	days := make([]calendar.Day, 0)
+	for _, day := range calendar.GetYearDays(2022) {
+		if !day.NoInput {
+			days = append(days, day)
+		}
+	}
+	if err := r.Build(days); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	for _, day := range days {
+		generated, err := r.Generate(ctx, day, 42, 0)
+		if errors.Is(err, context.DeadlineExceeded) {
+			t.Fatal(err)
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "no input generator") {
+				continue
+			}
+			t.Errorf("%s: couldn't generate: %v", day.ID(), err)
+			continue
+		}
+		if strings.HasSuffix(string(generated.Input), "\n\n") {
+			t.Errorf("%s: the generated input ends with an empty line", day.ID())
+		}
+
+		path := filepath.Join(t.TempDir(), "random.txt")
+		if err := os.WriteFile(path, generated.Input, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		run := r.RunInput(ctx, day, path, generated.Params...)
+		switch {
+		case run.Err != nil:
+			t.Errorf("%s: the generated input with seed %d fails: %v", day.ID(), generated.Seed, run.Err)
+		case run.Stopped || run.Record == nil:
+			t.Errorf("%s: the generated input with seed %d wasn't solved", day.ID(), generated.Seed)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package runner
+
+import (
+	"AoC22/internal/inputhandler"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryPath is where the runs are recorded, relative to the module root.
+// It's kept with the inputs, it's just as personal.
+var HistoryPath = filepath.Join(inputhandler.InputStoreDir, "history.jsonl")
+
+// HistoryEntry is a run of a day, the history file has one of them on every line.
+type HistoryEntry struct {
+	Time      time.Time     `json:"time"` // when the run started
+	Year      int           `json:"year"`
+	Day       int           `json:"day"`
+	Command   string        `json:"command"`            // the command of the tool that ran it, like "watch"
+	Checksum  string        `json:"checksum,omitempty"` // of the input, see inputhandler.GetInputChecksum()
+	ElapsedNs int64         `json:"elapsed_ns"`         // of the whole process, with the input reading
+	Parts     []HistoryPart `json:"parts,omitempty"`
+	Stopped   bool          `json:"stopped,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// HistoryPart is the answer of a part in a run.
+type HistoryPart struct {
+	Part      string `json:"part"`
+	Answer    string `json:"answer"` // image lines joined with '\n'
+	ElapsedNs int64  `json:"elapsed_ns"`
+	Stopped   bool   `json:"stopped,omitempty"` // the answer is the progress made
+}
+
+// NewHistoryEntry creates the entry of the run, started at the given time.
+func NewHistoryEntry(run DayRun, command string, start time.Time) HistoryEntry {
+
+	entry := HistoryEntry{
+		Time:      start,
+		Year:      run.Day.Year,
+		Day:       run.Day.Number,
+		Command:   command,
+		ElapsedNs: run.Elapsed.Nanoseconds(),
+		Stopped:   run.Stopped,
+	}
+	if run.Err != nil {
+		entry.Error = run.Err.Error()
+	}
+
+	if run.Record != nil {
+		entry.Checksum = run.Record.Checksum
+		for _, part := range run.Record.Results {
+			entry.Parts = append(entry.Parts, HistoryPart{
+				Part:      part.Part,
+				Answer:    part.AnswerText(),
+				ElapsedNs: part.ElapsedNs,
+				Stopped:   len(part.Stopped) > 0,
+			})
+		}
+	}
+
+	return entry
+}
+
+// SolveElapsed returns how long the solution took for the parts, without the input reading.
+func (e HistoryEntry) SolveElapsed() time.Duration {
+
+	var elapsed time.Duration
+	for _, part := range e.Parts {
+		elapsed += time.Duration(part.ElapsedNs)
+	}
+
+	return elapsed
+}
+
+// IsComplete tells if the run solved every part it was asked for, so its time can be compared.
+func (e HistoryEntry) IsComplete() bool {
+
+	if len(e.Error) > 0 || e.Stopped || len(e.Parts) == 0 {
+		return false
+	}
+	for _, part := range e.Parts {
+		if part.Stopped {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AppendHistory adds the entries to the end of the history file, it's created if needed.
+func AppendHistory(path string, entries ...HistoryEntry) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("couldn't create the directory of '%s': %w", path, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("couldn't open file '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	// one write for all, so the lines of parallel runs don't mix
+	var data []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("couldn't write file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadHistory loads the entries of the history file in the order they were added,
+// nil if there is no history yet.
+func LoadHistory(path string) ([]HistoryEntry, error) {
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open file '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	entries := make([]HistoryEntry, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // the image answers make long lines
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("couldn't parse line %d of '%s': %w", lineNumber, path, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't read file '%s': %w", path, err)
+	}
+
+	return entries, nil
+}
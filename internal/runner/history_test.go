@@ -0,0 +1,99 @@
+package runner
+
+import (
+	"AoC22/internal/calendar"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadHistoryMissing(t *testing.T) {
+
+	entries, err := LoadHistory(filepath.Join(t.TempDir(), "history.jsonl"))
+	if entries != nil || err != nil {
+		t.Errorf("got %v, %v, want no entries and no error", entries, err)
+	}
+}
+
+func TestAppendAndLoadHistory(t *testing.T) {
+
+	record, err := DecodeRecord([]byte(testRecordJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	day, _ := calendar.GetDay(2022, 10)
+	start := time.Date(2022, time.December, 10, 6, 0, 0, 0, time.UTC)
+
+	path := filepath.Join(t.TempDir(), "inputs", "history.jsonl") // the directory is created too
+	err = AppendHistory(path,
+		NewHistoryEntry(DayRun{Day: day, Record: record, Elapsed: time.Millisecond}, "all", start),
+		NewHistoryEntry(DayRun{Day: day, Err: errors.New("exit status 5")}, "all", start.Add(time.Minute)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendHistory(path, NewHistoryEntry(DayRun{Day: day, Stopped: true}, "watch", start.Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	solved := entries[0]
+	if !solved.Time.Equal(start) || solved.Year != 2022 || solved.Day != 10 || solved.Command != "all" || solved.Checksum != "abc" {
+		t.Errorf("got entry %+v, want the run of 2022/10 at %v", solved, start)
+	}
+	if len(solved.Parts) != 3 || solved.Parts[1].Answer != "#..#\n#..#" || !solved.Parts[2].Stopped {
+		t.Errorf("got parts %+v, want the answers of the record", solved.Parts)
+	}
+	if got, want := solved.SolveElapsed(), 60*time.Nanosecond; got != want {
+		t.Errorf("got solve time %v, want %v", got, want)
+	}
+
+	if entries[1].Error != "exit status 5" || entries[2].Command != "watch" || !entries[2].Stopped {
+		t.Errorf("got entries %+v and %+v, want the failed and the stopped run", entries[1], entries[2])
+	}
+}
+
+func TestLoadHistoryInvalid(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	data := "{\"year\":2022,\"day\":1}\n\n{\"year\":2022,\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadHistory(path); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("got error %v, want one about line 3", err)
+	}
+}
+
+func TestHistoryEntryIsComplete(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		entry HistoryEntry
+		want  bool
+	}{
+		{"solved", HistoryEntry{Parts: []HistoryPart{{Part: "Part1"}, {Part: "Part2"}}}, true},
+		{"one part asked for", HistoryEntry{Parts: []HistoryPart{{Part: "Part2"}}}, true},
+		{"no parts", HistoryEntry{}, false},
+		{"failed", HistoryEntry{Parts: []HistoryPart{{Part: "Part1"}}, Error: "exit status 5"}, false},
+		{"stopped", HistoryEntry{Parts: []HistoryPart{{Part: "Part1"}}, Stopped: true}, false},
+		{"part stopped", HistoryEntry{Parts: []HistoryPart{{Part: "Part1"}, {Part: "Part2", Stopped: true}}}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.entry.IsComplete(); got != test.want {
+			t.Errorf("%s: got %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Builds the solutions and runs them as separate processes, for the tools that
+// work with several days at once. Every run prints its results as a JSON record
+// (see outputhandler.RunRecord), so the answers can be compared.
+//
+// Suggested usage:
+//
+//	r, err := NewRunner()
+//	defer r.Close()
+//	err = r.Build(days)
+//	runs := r.RunAll(ctx, days, workers)
+package runner
+
+import (
+	"AoC22/internal/calendar"
+	"AoC22/internal/inputhandler"
+	"AoC22/internal/outputhandler"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Runner builds the solutions into a temporary directory and runs them from there.
+type Runner struct {
+	Root   string   // the module root, the paths of the days are relative to it
+	BinDir string   // where the binaries are built
+	Args   []string // passed to every run, like "-timeout", "1m"
+
+	Example bool // solve the worked examples instead of the inputs
+
+	OnRunDone func(run DayRun) // called by RunAll() after each run, from the workers
+
+	HistoryPath string // Run() adds the runs to this history file when set, see HistoryEntry
+	Command     string // the command of the tool recorded in the history, like "all"
+
+	historyMutex sync.Mutex
+}
+
+// NewRunner creates the runner for the module the working directory is in.
+func NewRunner() (*Runner, error) {
+
+	root, err := FindModuleRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	binDir, err := os.MkdirTemp("", "aoc-bin-")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create directory for the binaries: %w", err)
+	}
+
+	return &Runner{Root: root, BinDir: binDir}, nil
+}
+
+// Close removes the built binaries.
+func (r *Runner) Close() error {
+	return os.RemoveAll(r.BinDir)
+}
+
+// FindModuleRoot returns the closest directory with a go.mod, from the working directory up.
+func FindModuleRoot() (string, error) {
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("couldn't get the working directory: %w", err)
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found, run it inside the module")
+		}
+		dir = parent
+	}
+}
+
+// Build builds the solutions of the days with one go build call per year,
+// the days of different years have the same names.
+func (r *Runner) Build(days []calendar.Day) error {
+
+	packagesByYear := make(map[int][]string)
+	years := make([]int, 0)
+	for _, day := range days {
+		if _, ok := packagesByYear[day.Year]; !ok {
+			years = append(years, day.Year)
+		}
+		packagesByYear[day.Year] = append(packagesByYear[day.Year], day.Package())
+	}
+
+	for _, year := range years {
+		outDir := filepath.Join(r.BinDir, strconv.Itoa(year)) + string(filepath.Separator)
+		args := append([]string{"build", "-o", outDir}, packagesByYear[year]...)
+
+		cmd := exec.Command("go", args...)
+		cmd.Dir = r.Root
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("build failed: %v\n%s", err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
+
+// GetBinaryPath returns where the day's binary is built to.
+func (r *Runner) GetBinaryPath(day calendar.Day) string {
+	path := filepath.Join(r.BinDir, strconv.Itoa(day.Year), day.Name())
+	if runtime.GOOS == "windows" {
+		return path + ".exe"
+	}
+	return path
+}
+
+//-Running---------------------------------------------------------------------
+
+// DayRun is the outcome of running a day.
+type DayRun struct {
+	Day     calendar.Day
+	Record  *outputhandler.RunRecord // nil if the run failed before printing the results
+	Elapsed time.Duration            // of the whole process, with the input reading
+	Err     error                    // the error the solution printed, or why it couldn't run
+	Stopped bool                     // by a timeout or Ctrl-C, the record has the progress
+}
+
+// ErrorNoInput is returned for days without an input file.
+var ErrorNoInput = fmt.Errorf("no input")
+
+// Run runs the day's built binary on its input with the extra arguments.
+// The run is added to the history if HistoryPath is set, the runs of the examples are not.
+func (r *Runner) Run(ctx context.Context, day calendar.Day, extraArgs ...string) DayRun {
+
+	run := DayRun{Day: day}
+
+	args := []string{"-output", "json"}
+	if r.Example {
+		args = append(args, "-example")
+	} else if !day.NoInput {
+		if _, err := os.Stat(filepath.Join(r.Root, day.InputPath())); err != nil {
+			run.Err = ErrorNoInput
+			return run
+		}
+		args = append(args, "-f", day.InputPath()) // relative, shorter in the error messages
+	}
+	args = append(args, r.Args...)
+	args = append(args, extraArgs...)
+
+	start := time.Now()
+	run = r.runBinary(ctx, day, args)
+	if len(r.HistoryPath) > 0 && !r.Example {
+		r.historyMutex.Lock()
+		defer r.historyMutex.Unlock()
+		if err := AppendHistory(r.HistoryPath, NewHistoryEntry(run, r.Command, start)); err != nil {
+			outputhandler.PrintError("Warning", err)
+		}
+	}
+
+	return run
+}
+
+// RunInput runs the day's built binary like Run(), on the given input file instead of the day's.
+func (r *Runner) RunInput(ctx context.Context, day calendar.Day, inputPath string, extraArgs ...string) DayRun {
+
+	args := []string{"-output", "json", "-f", inputPath}
+	args = append(args, r.Args...)
+	args = append(args, extraArgs...)
+
+	return r.runBinary(ctx, day, args)
+}
+
+func (r *Runner) runBinary(ctx context.Context, day calendar.Day, args []string) DayRun {
+
+	run := DayRun{Day: day}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.GetBinaryPath(day), args...)
+	cmd.Dir = r.Root // the input path is relative to it
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	run.Elapsed = time.Since(start)
+
+	if stdout.Len() > 0 {
+		record, decodeErr := DecodeRecord(stdout.Bytes())
+		if decodeErr != nil && err == nil {
+			err = decodeErr
+		}
+		run.Record = record
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == int(inputhandler.ErrorCodeStopped):
+		run.Stopped = true
+	case errors.As(err, &exitErr):
+		run.Err = fmt.Errorf("%s", getErrorMessage(stderr.String(), exitErr))
+	default:
+		run.Err = err
+	}
+
+	return run
+}
+
+// RunAll runs the days on a pool of workers, the runs are returned in the order of the days.
+func (r *Runner) RunAll(ctx context.Context, days []calendar.Day, workers int, extraArgs ...string) []DayRun {
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	runs := make([]DayRun, len(days))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for dayIdx := range jobs {
+				runs[dayIdx] = r.Run(ctx, days[dayIdx], extraArgs...)
+				if r.OnRunDone != nil {
+					r.OnRunDone(runs[dayIdx])
+				}
+			}
+		}()
+	}
+
+	for dayIdx := range days {
+		jobs <- dayIdx
+	}
+	close(jobs)
+	wg.Wait()
+
+	return runs
+}
+
+// DecodeRecord decodes the JSON record printed with -output json.
+// Numbers are kept as json.Number, some answers don't fit into a float64.
+func DecodeRecord(data []byte) (*outputhandler.RunRecord, error) {
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var record outputhandler.RunRecord
+	if err := decoder.Decode(&record); err != nil {
+		return nil, fmt.Errorf("couldn't decode the results: %w", err)
+	}
+
+	return &record, nil
+}
+
+var escapeSequencePattern = regexp.MustCompile("\033\\[[0-9;?]*[A-Za-z]")
+
+// getErrorMessage finds the error the solution printed, or falls back to the exit code
+func getErrorMessage(stderr string, exitErr *exec.ExitError) string {
+
+	lines := strings.Split(escapeSequencePattern.ReplaceAllString(stderr, ""), "\n")
+
+	lastLine := ""
+	for lineIdx := len(lines) - 1; lineIdx >= 0; lineIdx-- {
+		line := strings.TrimSpace(lines[lineIdx])
+		if len(line) == 0 {
+			continue
+		}
+		if strings.HasPrefix(line, "Error") {
+			return line
+		}
+		if len(lastLine) == 0 {
+			lastLine = line
+		}
+	}
+
+	if len(lastLine) > 0 {
+		return fmt.Sprintf("%s (%v)", lastLine, exitErr)
+	}
+	return exitErr.Error()
+}
@@ -0,0 +1,132 @@
+package runner
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileWatcher polls files for changes. There is no notification API in the
+// standard library, and polling a few dozen files is cheap enough.
+type FileWatcher struct {
+	Paths    []string      // files and directories, the directories are walked for the Exts
+	Exts     []string      // like ".go", empty for every file in the directories
+	Interval time.Duration // between the polls
+	Debounce time.Duration // how long the files have to stay unchanged before a change is reported
+
+	snapshot map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewFileWatcher creates the watcher and takes the first snapshot of the files.
+func NewFileWatcher(paths []string, exts []string, interval, debounce time.Duration) *FileWatcher {
+
+	w := &FileWatcher{
+		Paths:    paths,
+		Exts:     exts,
+		Interval: interval,
+		Debounce: debounce,
+	}
+	w.snapshot = w.takeSnapshot()
+
+	return w
+}
+
+// Wait blocks until some of the files change and then stay unchanged for the
+// debounce time, like while an editor saves several files. Returns the changed
+// files, or the error of the context.
+func (w *FileWatcher) Wait(ctx context.Context) ([]string, error) {
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	var changed map[string]bool
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		snapshot := w.takeSnapshot()
+		if paths := diffSnapshots(w.snapshot, snapshot); len(paths) > 0 {
+			if changed == nil {
+				changed = make(map[string]bool)
+			}
+			for _, path := range paths {
+				changed[path] = true
+			}
+			lastChange = time.Now()
+			w.snapshot = snapshot
+			continue
+		}
+
+		if changed != nil && time.Since(lastChange) >= w.Debounce {
+			paths := make([]string, 0, len(changed))
+			for path := range changed {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			return paths, nil
+		}
+	}
+}
+
+func (w *FileWatcher) takeSnapshot() map[string]fileState {
+
+	snapshot := make(map[string]fileState)
+	for _, root := range w.Paths {
+		// missing files are fine, like an input that is not there yet
+		_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || (path != root && !w.isWatched(path)) {
+				return nil
+			}
+			if info, err := entry.Info(); err == nil {
+				snapshot[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+
+	return snapshot
+}
+
+func (w *FileWatcher) isWatched(path string) bool {
+
+	if len(w.Exts) == 0 {
+		return true
+	}
+	for _, ext := range w.Exts {
+		if strings.EqualFold(filepath.Ext(path), ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffSnapshots returns the files that were added, removed or modified
+func diffSnapshots(old, new map[string]fileState) []string {
+
+	paths := make([]string, 0)
+	for path, state := range new {
+		if oldState, ok := old[path]; !ok || oldState != state {
+			paths = append(paths, path)
+		}
+	}
+	for path := range old {
+		if _, ok := new[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
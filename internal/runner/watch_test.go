@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+
+	start := time.Date(2022, time.December, 1, 6, 0, 0, 0, time.UTC)
+	old := map[string]fileState{
+		"same.go":     {start, 10},
+		"touched.go":  {start, 10},
+		"resized.go":  {start, 10},
+		"removed.go":  {start, 10},
+		"replaced.go": {start, 10},
+	}
+	new := map[string]fileState{
+		"same.go":     {start, 10},
+		"touched.go":  {start.Add(time.Second), 10},
+		"resized.go":  {start, 11},
+		"added.go":    {start, 10},
+		"replaced.go": {start.Add(-time.Hour), 10}, // an older file copied over it
+	}
+
+	got := diffSnapshots(old, new)
+	sort.Strings(got)
+	if want := []string{"added.go", "removed.go", "replaced.go", "resized.go", "touched.go"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := diffSnapshots(old, old); len(got) != 0 {
+		t.Errorf("got %v for the same snapshots, want no changes", got)
+	}
+	if got := diffSnapshots(nil, map[string]fileState{"a.go": {start, 1}}); len(got) != 1 {
+		t.Errorf("got %v from no snapshot, want the added file", got)
+	}
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFileWatcherWait(t *testing.T) {
+
+	dir := t.TempDir()
+	input := filepath.Join(t.TempDir(), "input.txt") // a file is watched whatever its extension
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main")
+	writeTestFile(t, filepath.Join(dir, "old.go"), "package main")
+	writeTestFile(t, filepath.Join(dir, "notes.txt"), "ignored")
+	writeTestFile(t, input, "1\n2\n")
+
+	const debounce = 200 * time.Millisecond
+	w := NewFileWatcher([]string{dir, input}, []string{".go"}, 5*time.Millisecond, debounce)
+
+	// two saves close together are reported once, after the last one; the sizes
+	// change too, the modification times may be too coarse to tell
+	lastSave := make(chan time.Time, 1)
+	go func() {
+		writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n")
+		writeTestFile(t, filepath.Join(dir, "notes.txt"), "still ignored")
+		time.Sleep(debounce / 10)
+		writeTestFile(t, filepath.Join(dir, "new.go"), "package main")
+		if err := os.Remove(filepath.Join(dir, "old.go")); err != nil {
+			t.Error(err)
+		}
+		writeTestFile(t, input, "1\n2\n3\n")
+		lastSave <- time.Now()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	paths, err := w.Wait(ctx)
+	returned := time.Now()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{input, filepath.Join(dir, "main.go"), filepath.Join(dir, "new.go"), filepath.Join(dir, "old.go")}
+	sort.Strings(want)
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", paths, want)
+	}
+	if saved := <-lastSave; returned.Sub(saved) < debounce {
+		t.Errorf("returned %v after the last save, want at least the debounce of %v", returned.Sub(saved), debounce)
+	}
+
+	// the next wait starts from the files as they are now
+	ctx, cancel = context.WithTimeout(context.Background(), 3*debounce/2)
+	defer cancel()
+	if paths, err := w.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, %v, want no changes until the timeout", paths, err)
+	}
+}
+
+func TestFileWatcherWaitCanceled(t *testing.T) {
+
+	w := NewFileWatcher([]string{t.TempDir()}, nil, time.Millisecond, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if paths, err := w.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, %v, want the error of the context", paths, err)
+	}
+}